@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that restricts command calls by roles and permissions of the caller principal.
+
+The caller principal is attached to command arguments with WithPrincipal.
+A command can be called when the principal has any of the permissions required for the command.
+Commands without required permissions can be called by anyone, including anonymous callers.
+The principal is removed from the arguments before they are validated and passed to the command.
+
+Unauthorized calls return UnauthorizedError with code "NOT_AUTHENTICATED" when no principal
+is attached, or "ACCESS_DENIED" when the principal lacks the permissions.
+The error details contain the command name and the missing permissions.
+
+Configuration parameters
+ permissions:
+   <command name>: comma-separated list of roles or permissions required for the command
+ default_permissions: comma-separated list of roles or permissions required for commands not listed in permissions
+
+see
+ICommandInterceptor
+
+see
+Principal
+
+Example:
+ interceptor := NewAuthorizationInterceptor()
+ interceptor.Configure(config.NewConfigParamsFromTuples(
+ 	"permissions.get_dummies", "user,admin",
+ 	"permissions.delete_dummy", "admin",
+ ))
+ commandSet.AddInterceptor(interceptor)
+
+ args := WithPrincipal(run.NewParametersFromTuples("dummy_id", "1"), NewPrincipal("user1", "user"))
+ _, err := commandSet.Execute("123", "delete_dummy", args) // Result: ACCESS_DENIED error
+*/
+type AuthorizationInterceptor struct {
+	permissions        map[string][]string
+	defaultPermissions []string
+	lock               sync.RWMutex
+}
+
+// Creates a new interceptor without required permissions.
+// Returns *AuthorizationInterceptor
+func NewAuthorizationInterceptor() *AuthorizationInterceptor {
+	return &AuthorizationInterceptor{
+		permissions:        map[string][]string{},
+		defaultPermissions: []string{},
+	}
+}
+
+// Configures the interceptor with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config: *config.ConfigParams
+//  configuration parameters to set.
+func (c *AuthorizationInterceptor) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	permissions := config.GetSection("permissions")
+	for _, name := range permissions.Keys() {
+		c.permissions[name] = splitPermissions(permissions.GetAsString(name))
+	}
+
+	if value := config.GetAsNullableString("default_permissions"); value != nil {
+		c.defaultPermissions = splitPermissions(*value)
+	}
+}
+
+func splitPermissions(value string) []string {
+	result := []string{}
+	for _, permission := range strings.Split(value, ",") {
+		permission = strings.TrimSpace(permission)
+		if permission != "" {
+			result = append(result, permission)
+		}
+	}
+	return result
+}
+
+// Sets roles or permissions required for the command.
+// Parameters:
+//  - name: string
+//  the command name.
+//  - permissions: ...string
+//  the required roles or permissions. Any of them grants access to the command.
+func (c *AuthorizationInterceptor) SetPermissions(name string, permissions ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.permissions[name] = append([]string{}, permissions...)
+}
+
+// Sets roles or permissions required for commands without own permissions.
+// Parameters:
+//  - permissions: ...string
+//  the required roles or permissions.
+func (c *AuthorizationInterceptor) SetDefaultPermissions(permissions ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.defaultPermissions = append([]string{}, permissions...)
+}
+
+// Gets roles or permissions required for the command.
+// Parameters:
+//  - name: string
+//  the command name.
+// Returns []string
+// the required roles or permissions or empty list when the command is not restricted.
+func (c *AuthorizationInterceptor) Permissions(name string) []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if permissions, ok := c.permissions[name]; ok {
+		return append([]string{}, permissions...)
+	}
+	return append([]string{}, c.defaultPermissions...)
+}
+
+// Checks if the principal is allowed to call the command.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - name: string
+//  the command name.
+//  - principal: *Principal
+//  the caller principal or nil for anonymous callers.
+// Returns error
+// UnauthorizedError when the call is not allowed or nil otherwise.
+func (c *AuthorizationInterceptor) Authorize(correlationId string, name string, principal *Principal) error {
+	permissions := c.Permissions(name)
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	if principal == nil {
+		return errors.NewUnauthorizedError(
+			correlationId,
+			"NOT_AUTHENTICATED",
+			"Command "+name+" requires an authenticated caller",
+		).WithDetails("command", name).WithDetails("missing_permissions", permissions)
+	}
+
+	for _, permission := range permissions {
+		if principal.HasPermission(permission) {
+			return nil
+		}
+	}
+
+	return errors.NewUnauthorizedError(
+		correlationId,
+		"ACCESS_DENIED",
+		"Caller "+principal.Id+" is not allowed to call command "+name,
+	).WithDetails("command", name).
+		WithDetails("principal", principal.Id).
+		WithDetails("missing_permissions", permissions)
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *AuthorizationInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command when the caller principal is allowed to call it.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) with attached principal to pass to the command for execution.
+// Returns interface{}, error
+// the command result or UnauthorizedError when the call is not allowed.
+func (c *AuthorizationInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	if err := c.Authorize(correlationId, command.Name(), GetPrincipal(args)); err != nil {
+		return nil, err
+	}
+	return command.Execute(correlationId, WithoutPrincipal(args))
+}
+
+// Validates arguments of the wrapped command without the attached principal.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *AuthorizationInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(WithoutPrincipal(args))
+}
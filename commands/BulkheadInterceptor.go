@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that limits the number of concurrent command calls with a bulkhead.
+When the bulkhead is full, commands are rejected with InvalidStateError without being executed.
+
+see
+ICommandInterceptor
+
+see
+run.Bulkhead
+
+Example:
+ bulkhead := run.NewBulkhead(10, 100, time.Second)
+ commandSet.AddInterceptor(NewBulkheadInterceptor(bulkhead))
+*/
+type BulkheadInterceptor struct {
+	bulkhead *run.Bulkhead
+}
+
+// Creates a new interceptor that uses the bulkhead.
+// Parameters:
+//  - bulkhead *run.Bulkhead
+//  the bulkhead to call commands through.
+// Returns *BulkheadInterceptor
+func NewBulkheadInterceptor(bulkhead *run.Bulkhead) *BulkheadInterceptor {
+	if bulkhead == nil {
+		panic("Bulkhead cannot be nil")
+	}
+
+	return &BulkheadInterceptor{
+		bulkhead: bulkhead,
+	}
+}
+
+// Gets the bulkhead used by the interceptor.
+// Returns *run.Bulkhead
+// the bulkhead.
+func (c *BulkheadInterceptor) Bulkhead() *run.Bulkhead {
+	return c.bulkhead
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *BulkheadInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command when the bulkhead has a free slot.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or InvalidStateError when the bulkhead is full.
+func (c *BulkheadInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	return c.bulkhead.ExecuteOne(correlationId, command, args)
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *BulkheadInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
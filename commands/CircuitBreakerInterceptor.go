@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that calls commands through a circuit breaker.
+When the breaker is open, commands fail fast with ConnectionError without being executed.
+
+see
+ICommandInterceptor
+
+see
+run.CircuitBreaker
+
+Example:
+ breaker := run.NewCircuitBreaker("payments")
+ breaker.SetEvent(NewEvent("circuit_changed"))
+
+ commandSet.AddInterceptor(NewCircuitBreakerInterceptor(breaker))
+*/
+type CircuitBreakerInterceptor struct {
+	breaker *run.CircuitBreaker
+}
+
+// Creates a new interceptor that uses the circuit breaker.
+// Parameters:
+//  - breaker *run.CircuitBreaker
+//  the circuit breaker to call commands through.
+// Returns *CircuitBreakerInterceptor
+func NewCircuitBreakerInterceptor(breaker *run.CircuitBreaker) *CircuitBreakerInterceptor {
+	if breaker == nil {
+		panic("Circuit breaker cannot be nil")
+	}
+
+	return &CircuitBreakerInterceptor{
+		breaker: breaker,
+	}
+}
+
+// Gets the circuit breaker used by the interceptor.
+// Returns *run.CircuitBreaker
+// the circuit breaker.
+func (c *CircuitBreakerInterceptor) Breaker() *run.CircuitBreaker {
+	return c.breaker
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *CircuitBreakerInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command through the circuit breaker.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or ConnectionError when the breaker is open.
+func (c *CircuitBreakerInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	return c.breaker.ExecuteOne(correlationId, command, args)
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *CircuitBreakerInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
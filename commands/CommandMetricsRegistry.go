@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+Call statistics of a single command collected by CommandMetricsRegistry.
+
+see
+CommandMetricsRegistry
+*/
+type CommandStats struct {
+	Name          string        `json:"name"`
+	Calls         int64         `json:"calls"`
+	Errors        int64         `json:"errors"`
+	LastDuration  time.Duration `json:"last_duration"`
+	MaxDuration   time.Duration `json:"max_duration"`
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+// Gets the average duration of the command calls.
+// Returns time.Duration
+// the average duration or 0 when the command wasn't called.
+func (c *CommandStats) AverageDuration() time.Duration {
+	if c.Calls == 0 {
+		return 0
+	}
+	return c.TotalDuration / time.Duration(c.Calls)
+}
+
+/*
+Registry of per-command latency and error counters.
+It is filled by MetricsInterceptor and can be queried at any time.
+
+see
+MetricsInterceptor
+
+Example:
+ registry := NewCommandMetricsRegistry()
+ commandSet.AddInterceptor(NewMetricsInterceptor(registry))
+ ...
+ stats := registry.Get("get_dummies")
+ fmt.Println(stats.Calls, stats.Errors, stats.AverageDuration())
+*/
+type CommandMetricsRegistry struct {
+	stats map[string]*CommandStats
+	lock  sync.Mutex
+}
+
+// Creates a new empty metrics registry.
+// Returns *CommandMetricsRegistry
+func NewCommandMetricsRegistry() *CommandMetricsRegistry {
+	return &CommandMetricsRegistry{
+		stats: map[string]*CommandStats{},
+	}
+}
+
+// Records a command call.
+// Parameters:
+//  - name: string
+//  the name of the called command.
+//  - duration: time.Duration
+//  the call duration.
+//  - err: error
+//  the error returned by the call or nil when it succeeded.
+func (c *CommandMetricsRegistry) Record(name string, duration time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats, ok := c.stats[name]
+	if !ok {
+		stats = &CommandStats{Name: name}
+		c.stats[name] = stats
+	}
+
+	stats.Calls++
+	if err != nil {
+		stats.Errors++
+	}
+	stats.LastDuration = duration
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+}
+
+// Gets statistics of the command.
+// Parameters:
+//  - name: string
+//  the name of the command.
+// Returns *CommandStats
+// a copy of the command statistics or nil when the command wasn't called.
+func (c *CommandMetricsRegistry) Get(name string) *CommandStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats, ok := c.stats[name]
+	if !ok {
+		return nil
+	}
+	result := *stats
+	return &result
+}
+
+// Gets statistics of all called commands sorted by command names.
+// Returns []*CommandStats
+// copies of the command statistics.
+func (c *CommandMetricsRegistry) GetAll() []*CommandStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	result := make([]*CommandStats, 0, len(c.stats))
+	for _, stats := range c.stats {
+		copied := *stats
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Clears all collected statistics.
+func (c *CommandMetricsRegistry) Reset() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stats = map[string]*CommandStats{}
+}
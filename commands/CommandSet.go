@@ -200,6 +200,38 @@ func (c *CommandSet) RemoveListener(listener IEventListener) {
 	}
 }
 
+// Sets the mode used to deliver notifications for all events in this command set.
+// Events that do not support delivery modes are not changed.
+// see
+// EventDeliveryMode
+// Parameters:
+//  - mode: EventDeliveryMode
+//  the delivery mode.
+//  - queueSize: int
+//  the size of per-listener queues in DeliveryQueued mode.
+func (c *CommandSet) SetDeliveryMode(mode EventDeliveryMode, queueSize int) {
+	for _, event := range c.events {
+		if e, ok := event.(*Event); ok {
+			e.SetDeliveryMode(mode, queueSize)
+		}
+	}
+}
+
+// Sets the function called when notifications of events in this command set fail to be delivered.
+// Events that do not support failure handlers are not changed.
+// see
+// EventFailureHandler
+// Parameters:
+//  - handler: EventFailureHandler
+//  the failure handler or nil to ignore failures.
+func (c *CommandSet) SetFailureHandler(handler EventFailureHandler) {
+	for _, event := range c.events {
+		if e, ok := event.(*Event); ok {
+			e.SetFailureHandler(handler)
+		}
+	}
+}
+
 // Adds a command interceptor to this command set.
 // see
 // ICommandInterceptor
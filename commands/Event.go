@@ -1,25 +1,67 @@
 package commands
 
 import (
+	"fmt"
+	"sync"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
 	"github.com/pip-services3-go/pip-services3-commons-go/run"
 )
 
 /*
 Concrete implementation of IEvent interface. It allows to send asynchronous
 notifications to multiple subscribed listeners.
+
+By default listeners are notified synchronously. Use SetDeliveryMode to notify them
+asynchronously or through bounded per-listener queues. Panics in listeners are recovered
+in all modes and reported together with dropped notifications to the failure handler.
+Listeners can be added and removed while notifications are in flight.
+
+When the event is no longer needed in async or queued mode it shall be closed
+to deliver pending notifications and stop the queues.
+
+see
+EventDeliveryMode
+
 Example:
  event: = NewEvent("my_event");
- 
+ event.SetDeliveryMode(DeliveryQueued, 100);
+
  event.AddListener(myListener);
- 
+
  event.Notify("123", Parameters.fromTuples(
    "param1", "ABC",
    "param2", 123
  ));
+ ...
+ event.Close("123");
 */
 type Event struct {
-	name      string
-	listeners []IEventListener
+	name           string
+	subscriptions  []*eventSubscription
+	mode           EventDeliveryMode
+	queueSize      int
+	failureHandler EventFailureHandler
+	closed         bool
+	lock           sync.RWMutex
+	pending        sync.WaitGroup
+}
+
+type eventNotification struct {
+	correlationId string
+	source        IEvent
+	args          *run.Parameters
+}
+
+type eventFailure struct {
+	listener IEventListener
+	err      error
+}
+
+type eventSubscription struct {
+	listener IEventListener
+	queue    chan *eventNotification
 }
 
 // Creates a new event and assigns its name.
@@ -36,8 +78,9 @@ func NewEvent(name string) *Event {
 	}
 
 	return &Event{
-		name:      name,
-		listeners: []IEventListener{},
+		name:          name,
+		subscriptions: []*eventSubscription{},
+		mode:          DeliverySync,
 	}
 }
 
@@ -54,7 +97,63 @@ func (c *Event) Name() string {
 // a list of listeners.
 
 func (c *Event) Listeners() []IEventListener {
-	return c.listeners
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	listeners := make([]IEventListener, len(c.subscriptions))
+	for i, subscription := range c.subscriptions {
+		listeners[i] = subscription.listener
+	}
+	return listeners
+}
+
+// Gets the mode used to deliver notifications to listeners.
+// Returns EventDeliveryMode
+// the delivery mode.
+
+func (c *Event) DeliveryMode() EventDeliveryMode {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.mode
+}
+
+// Sets the mode used to deliver notifications to listeners.
+// When queues are replaced, notifications pending in the old queues are still delivered.
+// Parameters:
+//  - mode: EventDeliveryMode
+//  	the delivery mode.
+//  - queueSize: int
+//  	the size of per-listener queues in DeliveryQueued mode.
+
+func (c *Event) SetDeliveryMode(mode EventDeliveryMode, queueSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	c.mode = mode
+	c.queueSize = queueSize
+
+	subscriptions := make([]*eventSubscription, len(c.subscriptions))
+	for i, subscription := range c.subscriptions {
+		c.stopQueue(subscription)
+		subscriptions[i] = c.subscribe(subscription.listener)
+	}
+	c.subscriptions = subscriptions
+}
+
+// Sets the function called when a notification fails to be delivered to a listener.
+// Parameters:
+//  - handler: EventFailureHandler
+//  	the failure handler or nil to ignore failures.
+
+func (c *Event) SetFailureHandler(handler EventFailureHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.failureHandler = handler
 }
 
 // Adds a listener to receive notifications when this event is fired.
@@ -63,18 +162,31 @@ func (c *Event) Listeners() []IEventListener {
 //  	the listener reference to add.
 
 func (c *Event) AddListener(listener IEventListener) {
-	c.listeners = append(c.listeners, listener)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	subscriptions := make([]*eventSubscription, len(c.subscriptions), len(c.subscriptions)+1)
+	copy(subscriptions, c.subscriptions)
+	c.subscriptions = append(subscriptions, c.subscribe(listener))
 }
 
 // Removes a listener, so that it no longer receives notifications for this event.
+// Notifications already queued for the listener are still delivered.
 // Parameters:
 //  - listener: IEventListener
 //  	the listener reference to remove.
 
 func (c *Event) RemoveListener(listener IEventListener) {
-	for i, l := range c.listeners {
-		if listener == l {
-			c.listeners = append(c.listeners[:i], c.listeners[i+1:]...)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i, subscription := range c.subscriptions {
+		if listener == subscription.listener {
+			c.stopQueue(subscription)
+
+			subscriptions := make([]*eventSubscription, 0, len(c.subscriptions)-1)
+			subscriptions = append(subscriptions, c.subscriptions[:i]...)
+			c.subscriptions = append(subscriptions, c.subscriptions[i+1:]...)
 			break
 		}
 	}
@@ -88,7 +200,128 @@ func (c *Event) RemoveListener(listener IEventListener) {
 //  	the parameters to raise this event with.
 
 func (c *Event) Notify(correlationId string, args *run.Parameters) {
-	for _, listener := range c.listeners {
-		listener.OnEvent(correlationId, c, args)
+	c.notifyFrom(correlationId, c, args)
+}
+
+// Notifies listeners on behalf of the source event.
+// Listeners and the failure handler receive the source instead of this event.
+func (c *Event) notifyFrom(correlationId string, source IEvent, args *run.Parameters) {
+	c.lock.RLock()
+	subscriptions := c.subscriptions
+	mode := c.mode
+	closed := c.closed
+	handler := c.failureHandler
+
+	if mode == DeliverySync || len(subscriptions) == 0 {
+		c.lock.RUnlock()
+		for _, subscription := range subscriptions {
+			c.deliver(correlationId, source, subscription.listener, args)
+		}
+		return
+	}
+
+	// Queues and pending deliveries are changed only under write lock.
+	// Failures are reported after the lock is released to let handlers change the event.
+	failures := []*eventFailure{}
+	for _, subscription := range subscriptions {
+		if closed {
+			failures = append(failures, &eventFailure{subscription.listener, errors.NewInvalidStateError(
+				correlationId, "EVENT_CLOSED", "Event "+source.Name()+" is closed",
+			).WithDetails("event", source.Name())})
+			continue
+		}
+
+		if mode == DeliveryAsync {
+			c.pending.Add(1)
+			go func(listener IEventListener) {
+				defer c.pending.Done()
+				c.deliver(correlationId, source, listener, args)
+			}(subscription.listener)
+			continue
+		}
+
+		select {
+		case subscription.queue <- &eventNotification{correlationId: correlationId, source: source, args: args}:
+		default:
+			failures = append(failures, &eventFailure{subscription.listener, errors.NewInvalidStateError(
+				correlationId, "EVENT_QUEUE_FULL", "Event queue for "+source.Name()+" is full",
+			).WithDetails("event", source.Name()).WithDetails("queue_size", cap(subscription.queue))})
+		}
+	}
+	c.lock.RUnlock()
+
+	for _, failure := range failures {
+		c.reportFailure(handler, correlationId, source, failure.listener, failure.err)
+	}
+}
+
+// Closes the event. It waits until pending asynchronous and queued notifications
+// are delivered and stops listener queues. Notifications fired after closing
+// in DeliveryAsync or DeliveryQueued mode are reported as failed.
+// Parameters:
+//  - correlationId: string
+//  	(optional) transaction id to trace execution through call chain.
+// Returns error
+
+func (c *Event) Close(correlationId string) error {
+	c.lock.Lock()
+	c.closed = true
+	for _, subscription := range c.subscriptions {
+		c.stopQueue(subscription)
+	}
+	c.lock.Unlock()
+
+	c.pending.Wait()
+	return nil
+}
+
+// Creates a subscription and starts its queue in DeliveryQueued mode.
+// Shall be called under write lock.
+func (c *Event) subscribe(listener IEventListener) *eventSubscription {
+	subscription := &eventSubscription{listener: listener}
+	if c.mode != DeliveryQueued || c.closed {
+		return subscription
+	}
+
+	subscription.queue = make(chan *eventNotification, c.queueSize)
+	c.pending.Add(1)
+	go func(queue <-chan *eventNotification) {
+		defer c.pending.Done()
+		for notification := range queue {
+			c.deliver(notification.correlationId, notification.source, listener, notification.args)
+		}
+	}(subscription.queue)
+	return subscription
+}
+
+// Stops the subscription queue after pending notifications are delivered.
+// Shall be called under write lock.
+func (c *Event) stopQueue(subscription *eventSubscription) {
+	if subscription.queue != nil {
+		close(subscription.queue)
+		subscription.queue = nil
+	}
+}
+
+func (c *Event) deliver(correlationId string, source IEvent, listener IEventListener, args *run.Parameters) {
+	defer func() {
+		if r := recover(); r != nil {
+			message := convert.StringConverter.ToString(r)
+			c.lock.RLock()
+			handler := c.failureHandler
+			c.lock.RUnlock()
+
+			c.reportFailure(handler, correlationId, source, listener, errors.NewInvocationError(
+				correlationId, "EVENT_LISTENER_FAILED", "Listener of event "+source.Name()+" failed: "+message,
+			).WithDetails("event", source.Name()).WithDetails("listener", fmt.Sprintf("%T", listener)))
+		}
+	}()
+
+	listener.OnEvent(correlationId, source, args)
+}
+
+func (c *Event) reportFailure(handler EventFailureHandler, correlationId string, source IEvent, listener IEventListener, err error) {
+	if handler != nil {
+		handler(correlationId, source, listener, err)
 	}
 }
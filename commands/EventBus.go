@@ -0,0 +1,294 @@
+package commands
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+)
+
+// Descriptor to register the default event bus in references.
+var EventBusDescriptor = refer.NewDescriptor("pip-services", "event-bus", "default", "default", "1.0")
+
+// Function that decides if a notification shall be delivered to a subscriber.
+// Returns true to deliver the notification and false to skip it.
+type EventFilter func(correlationId string, event IEvent, args *run.Parameters) bool
+
+/*
+In-process event bus that lets components publish and subscribe to events by name
+without sharing a command set.
+
+Events are named with dot-separated segments like "orders.created". Subscribers use
+patterns where "*" matches exactly one segment and "**" matches any number of segments,
+so "orders.*" receives "orders.created" but not "orders.items.added" and "orders.**" receives both.
+
+Every subscription delivers notifications through its own Event, so delivery modes,
+per-subscriber queues, panic recovery and failure handling work the same way as in Event.
+Listeners receive an event named after the published name, which has no listeners of its own.
+
+see
+Event
+
+see
+EventSubscription
+
+Example:
+ bus := NewEventBus()
+ references.Put(EventBusDescriptor, bus)
+ ...
+ subscription := bus.Subscribe("orders.*", myListener)
+
+ bus.Publish("123", "orders.created", run.NewParametersFromTuples("id", "1"))
+ ...
+ subscription.Unsubscribe()
+*/
+type EventBus struct {
+	subscriptions  []*EventSubscription
+	mode           EventDeliveryMode
+	queueSize      int
+	failureHandler EventFailureHandler
+	lock           sync.RWMutex
+}
+
+// Creates a new event bus.
+// Returns *EventBus
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscriptions: []*EventSubscription{},
+		mode:          DeliverySync,
+	}
+}
+
+// Sets the mode used to deliver notifications to subscribers.
+// see
+// EventDeliveryMode
+// Parameters:
+//  - mode: EventDeliveryMode
+//  the delivery mode.
+//  - queueSize: int
+//  the size of per-subscriber queues in DeliveryQueued mode.
+func (c *EventBus) SetDeliveryMode(mode EventDeliveryMode, queueSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.mode = mode
+	c.queueSize = queueSize
+	for _, subscription := range c.subscriptions {
+		subscription.event.SetDeliveryMode(mode, queueSize)
+	}
+}
+
+// Sets the function called when notifications fail to be delivered to subscribers.
+// Parameters:
+//  - handler: EventFailureHandler
+//  the failure handler or nil to ignore failures.
+func (c *EventBus) SetFailureHandler(handler EventFailureHandler) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.failureHandler = handler
+	for _, subscription := range c.subscriptions {
+		subscription.event.SetFailureHandler(handler)
+	}
+}
+
+// Subscribes a listener to events which names match the pattern.
+// Parameters:
+//  - pattern: string
+//  the event name pattern with "*" and "**" wildcards.
+//  - listener: IEventListener
+//  the listener to notify.
+// Returns *EventSubscription
+// the subscription handle to unsubscribe the listener.
+func (c *EventBus) Subscribe(pattern string, listener IEventListener) *EventSubscription {
+	return c.SubscribeWithFilter(pattern, listener, nil)
+}
+
+// Subscribes a listener to events which names match the pattern
+// and which notifications pass the filter.
+// Parameters:
+//  - pattern: string
+//  the event name pattern with "*" and "**" wildcards.
+//  - listener: IEventListener
+//  the listener to notify.
+//  - filter: EventFilter
+//  (optional) the function to select notifications for the listener.
+// Returns *EventSubscription
+// the subscription handle to unsubscribe the listener.
+func (c *EventBus) SubscribeWithFilter(pattern string, listener IEventListener, filter EventFilter) *EventSubscription {
+	if pattern == "" {
+		panic("Pattern cannot be empty")
+	}
+	if listener == nil {
+		panic("Listener cannot be nil")
+	}
+
+	subscription := &EventSubscription{
+		bus:      c,
+		pattern:  pattern,
+		listener: listener,
+		filter:   filter,
+		event:    NewEvent(pattern),
+	}
+	subscription.event.AddListener(subscription)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	subscription.event.SetDeliveryMode(c.mode, c.queueSize)
+	subscription.event.SetFailureHandler(c.failureHandler)
+
+	subscriptions := make([]*EventSubscription, len(c.subscriptions), len(c.subscriptions)+1)
+	copy(subscriptions, c.subscriptions)
+	c.subscriptions = append(subscriptions, subscription)
+	return subscription
+}
+
+func (c *EventBus) unsubscribe(subscription *EventSubscription) {
+	c.lock.Lock()
+	for i, s := range c.subscriptions {
+		if s == subscription {
+			subscriptions := make([]*EventSubscription, 0, len(c.subscriptions)-1)
+			subscriptions = append(subscriptions, c.subscriptions[:i]...)
+			c.subscriptions = append(subscriptions, c.subscriptions[i+1:]...)
+			break
+		}
+	}
+	c.lock.Unlock()
+
+	// Stops the subscriber queue without waiting, so it can unsubscribe from its own listener
+	subscription.event.RemoveListener(subscription)
+}
+
+// Publishes an event and notifies all subscribers which patterns match the event name.
+// The bus keeps no state for published names, so names can contain ids like "orders.123.created".
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - eventName: string
+//  the name of the event to publish.
+//  - args: *run.Parameters
+//  the event arguments (parameters).
+func (c *EventBus) Publish(correlationId string, eventName string, args *run.Parameters) {
+	if eventName == "" {
+		return
+	}
+
+	c.lock.RLock()
+	subscriptions := c.subscriptions
+	c.lock.RUnlock()
+
+	// Listeners receive an event with the published name
+	var source *Event
+	for _, subscription := range subscriptions {
+		if MatchEventName(subscription.pattern, eventName) {
+			if source == nil {
+				source = NewEvent(eventName)
+			}
+			subscription.event.notifyFrom(correlationId, source, args)
+		}
+	}
+}
+
+// Closes the bus. It waits until pending asynchronous and queued notifications are delivered.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+// Returns error
+func (c *EventBus) Close(correlationId string) error {
+	c.lock.RLock()
+	subscriptions := c.subscriptions
+	c.lock.RUnlock()
+
+	for _, subscription := range subscriptions {
+		subscription.event.Close(correlationId)
+	}
+	return nil
+}
+
+// Checks if the event name matches the pattern.
+// The pattern segments are separated by dots, "*" matches exactly one segment
+// and "**" matches any number of segments.
+// Parameters:
+//  - pattern: string
+//  the event name pattern.
+//  - name: string
+//  the event name to match.
+// Returns bool
+// true if the name matches the pattern and false otherwise.
+func MatchEventName(pattern string, name string) bool {
+	return matchEventSegments(strings.Split(pattern, "."), strings.Split(name, "."))
+}
+
+func matchEventSegments(pattern []string, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchEventSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(name) == 0 {
+		return false
+	}
+	if pattern[0] != "*" && pattern[0] != name[0] {
+		return false
+	}
+	return matchEventSegments(pattern[1:], name[1:])
+}
+
+/*
+Subscription of a listener to the event bus. It is used to unsubscribe the listener.
+
+see
+EventBus
+*/
+type EventSubscription struct {
+	bus      *EventBus
+	pattern  string
+	listener IEventListener
+	filter   EventFilter
+	event    *Event
+}
+
+// Gets the event name pattern of the subscription.
+// Returns string
+// the event name pattern.
+func (c *EventSubscription) Pattern() string {
+	return c.pattern
+}
+
+// Gets the subscribed listener.
+// Returns IEventListener
+// the subscribed listener.
+func (c *EventSubscription) Listener() IEventListener {
+	return c.listener
+}
+
+// Unsubscribes the listener from the event bus.
+// It is safe to call the method several times.
+func (c *EventSubscription) Unsubscribe() {
+	c.bus.unsubscribe(c)
+}
+
+// Notifies the subscribed listener when the notification passes the filter.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - e: IEvent
+//  a fired event.
+//  - value: *run.Parameters
+//  event arguments.
+func (c *EventSubscription) OnEvent(correlationId string, e IEvent, value *run.Parameters) {
+	if c.filter != nil && !c.filter(correlationId, e, value) {
+		return
+	}
+	c.listener.OnEvent(correlationId, e, value)
+}
@@ -0,0 +1,29 @@
+package commands
+
+/*
+Defines how Event delivers notifications to its listeners.
+
+DeliverySync - listeners are called one by one in the goroutine that fires the event.
+
+DeliveryAsync - every listener is called in a separate goroutine and the event does not wait for it (fire-and-forget).
+
+DeliveryQueued - every listener has its own bounded queue processed by a dedicated goroutine.
+When the queue is full the notification is dropped and reported as a delivery failure.
+*/
+type EventDeliveryMode int
+
+const (
+	DeliverySync EventDeliveryMode = iota
+	DeliveryAsync
+	DeliveryQueued
+)
+
+// Function that is called when an event fails to deliver a notification to a listener.
+// The error is InvocationError when the listener panics
+// and InvalidStateError when the notification was dropped.
+//
+// Example:
+//  event.SetFailureHandler(func(correlationId string, event IEvent, listener IEventListener, err error) {
+//  	logger.Error(correlationId, err, "Failed to deliver event %s", event.Name())
+//  })
+type EventFailureHandler func(correlationId string, event IEvent, listener IEventListener, err error)
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+)
+
+/*
+Record about a command call written by LoggingInterceptor.
+Arguments are already redacted when the record reaches a sink.
+
+see
+LoggingInterceptor
+*/
+type CommandLogEntry struct {
+	Time          time.Time
+	CorrelationId string
+	Command       string
+	Args          map[string]interface{}
+	Duration      time.Duration
+	Error         error
+}
+
+/*
+Interface for sinks that receive records about command calls from LoggingInterceptor.
+
+see
+LoggingInterceptor
+
+Example:
+ type MyLogSink struct {
+ 	logger ILogger
+ }
+
+ func (c *MyLogSink) Log(entry *CommandLogEntry) {
+ 	c.logger.Debug(entry.CorrelationId, "Called %s with %v", entry.Command, entry.Args)
+ }
+*/
+type ICommandLogSink interface {
+	// Writes a record about a command call.
+	// Parameters:
+	//  - entry: *CommandLogEntry
+	//  the record to write.
+	Log(entry *CommandLogEntry)
+}
+
+// Function that implements ICommandLogSink interface.
+type CommandLogSinkFunc func(entry *CommandLogEntry)
+
+// Writes a record about a command call by calling the function.
+// Parameters:
+//  - entry: *CommandLogEntry
+//  the record to write.
+func (c CommandLogSinkFunc) Log(entry *CommandLogEntry) {
+	c(entry)
+}
+
+/*
+Command log sink that writes records as text lines into io.Writer.
+
+Example:
+ sink := NewWriterCommandLogSink(os.Stdout)
+ commandSet.AddInterceptor(NewLoggingInterceptor(sink))
+
+ // Console output: 2024-03-15T10:00:00.000Z [123] get_dummies args={"paging":{"take":10}} duration=2ms
+*/
+type WriterCommandLogSink struct {
+	writer io.Writer
+	lock   sync.Mutex
+}
+
+// Creates a new sink that writes into the writer.
+// Parameters:
+//  - writer: io.Writer
+//  the writer to write records into.
+// Returns *WriterCommandLogSink
+func NewWriterCommandLogSink(writer io.Writer) *WriterCommandLogSink {
+	if writer == nil {
+		panic("Writer cannot be nil")
+	}
+
+	return &WriterCommandLogSink{
+		writer: writer,
+	}
+}
+
+// Writes a record about a command call as a text line.
+// Parameters:
+//  - entry: *CommandLogEntry
+//  the record to write.
+func (c *WriterCommandLogSink) Log(entry *CommandLogEntry) {
+	args, _ := convert.ToJson(entry.Args)
+	line := fmt.Sprintf("%s [%s] %s args=%s duration=%s",
+		entry.Time.UTC().Format("2006-01-02T15:04:05.000Z"),
+		entry.CorrelationId, entry.Command, args,
+		entry.Duration.Round(time.Millisecond),
+	)
+	if entry.Error != nil {
+		line += " error=" + entry.Error.Error()
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	fmt.Fprintln(c.writer, line)
+}
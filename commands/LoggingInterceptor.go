@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	refl "reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+// Value written instead of redacted arguments.
+const RedactedValue = "***"
+
+// Maximum nesting of logged arguments. Deeper values are redacted to avoid endless cycles.
+const maxRedactionDepth = 32
+
+/*
+Command interceptor that logs command calls with correlation ids, command names,
+arguments, durations and errors through a pluggable sink.
+
+Values of arguments which names contain any of the redacted keys are replaced with "***".
+The keys are compared ignoring case, underscores and dashes in nested maps, arrays, structs and value maps like
+run.Parameters as well. Arguments are copied into generic maps and slices before they reach the sink.
+By default "password", "secret", "token", "api_key", "access_key" and "credential" are redacted.
+
+see
+ICommandInterceptor
+
+see
+ICommandLogSink
+
+Example:
+ interceptor := NewLoggingInterceptor(NewWriterCommandLogSink(os.Stdout))
+ interceptor.SetRedactedKeys("password", "ssn")
+
+ commandSet.AddInterceptor(interceptor)
+*/
+type LoggingInterceptor struct {
+	sink         ICommandLogSink
+	redactedKeys []string
+	lock         sync.RWMutex
+}
+
+// Creates a new interceptor that logs command calls into the sink.
+// Parameters:
+//  - sink: ICommandLogSink
+//  the sink to write records into.
+// Returns *LoggingInterceptor
+func NewLoggingInterceptor(sink ICommandLogSink) *LoggingInterceptor {
+	if sink == nil {
+		panic("Log sink cannot be nil")
+	}
+
+	return &LoggingInterceptor{
+		sink:         sink,
+		redactedKeys: []string{"password", "secret", "token", "api_key", "access_key", "credential"},
+	}
+}
+
+// Gets the sink used by the interceptor.
+// Returns ICommandLogSink
+// the log sink.
+func (c *LoggingInterceptor) Sink() ICommandLogSink {
+	return c.sink
+}
+
+// Gets keys of arguments which values are redacted.
+// Returns []string
+// the redacted keys.
+func (c *LoggingInterceptor) RedactedKeys() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return append([]string{}, c.redactedKeys...)
+}
+
+// Sets keys of arguments which values are redacted.
+// Parameters:
+//  - keys: ...string
+//  the redacted keys.
+func (c *LoggingInterceptor) SetRedactedKeys(keys ...string) {
+	redactedKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redactedKeys[i] = strings.ToLower(key)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.redactedKeys = redactedKeys
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *LoggingInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command and writes a record about the call into the sink.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or error.
+func (c *LoggingInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	// Arguments are redacted before the call in case the command changes them
+	var redacted map[string]interface{}
+	if args != nil {
+		redacted, _ = c.redactValue(args.Value(), 0).(map[string]interface{})
+	}
+
+	start := time.Now()
+	result, err := command.Execute(correlationId, args)
+
+	c.sink.Log(&CommandLogEntry{
+		Time:          start,
+		CorrelationId: correlationId,
+		Command:       command.Name(),
+		Args:          redacted,
+		Duration:      time.Since(start),
+		Error:         err,
+	})
+	return result, err
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *LoggingInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
+
+// Normalizes keys so "apiKey", "ApiKey" and "api-key" match "api_key".
+func normalizeRedactedKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "_", "")
+	return strings.ReplaceAll(key, "-", "")
+}
+
+func (c *LoggingInterceptor) isRedacted(key string) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	key = normalizeRedactedKey(key)
+	for _, redactedKey := range c.redactedKeys {
+		if strings.Contains(key, normalizeRedactedKey(redactedKey)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Copies the value into generic maps and slices and replaces values under redacted keys.
+// Typed maps, structs, pointers and value maps like run.Parameters are walked as well,
+// so secrets can't leak through types the sink doesn't know about.
+func (c *LoggingInterceptor) redactValue(value interface{}, depth int) interface{} {
+	if value == nil {
+		return nil
+	}
+	if depth > maxRedactionDepth {
+		return RedactedValue
+	}
+
+	// AnyValueMap, StringValueMap, run.Parameters and similar wrappers
+	if wrapper, ok := value.(interface{ InnerValue() interface{} }); ok {
+		return c.redactValue(wrapper.InnerValue(), depth+1)
+	}
+	// Values with own JSON format like time.Time are logged the way they are serialized
+	if _, ok := value.(json.Marshaler); ok {
+		buffer, err := json.Marshal(value)
+		if err != nil {
+			return RedactedValue
+		}
+		var generic interface{}
+		if err = json.Unmarshal(buffer, &generic); err != nil {
+			return RedactedValue
+		}
+		return c.redactValue(generic, depth+1)
+	}
+
+	v := refl.ValueOf(value)
+	switch v.Kind() {
+	case refl.Ptr, refl.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return c.redactValue(v.Elem().Interface(), depth+1)
+
+	case refl.Map:
+		result := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if c.isRedacted(key) {
+				result[key] = RedactedValue
+			} else {
+				result[key] = c.redactValue(iter.Value().Interface(), depth+1)
+			}
+		}
+		return result
+
+	case refl.Slice, refl.Array:
+		if v.Kind() == refl.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == refl.Uint8 {
+			return value
+		}
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = c.redactValue(v.Index(i).Interface(), depth+1)
+		}
+		return result
+
+	case refl.Struct:
+		result := map[string]interface{}{}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := field.Name
+			if tag := field.Tag.Get("json"); tag != "" {
+				if tag == "-" {
+					continue
+				}
+				if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+					name = tagName
+				}
+			}
+
+			if c.isRedacted(name) {
+				result[name] = RedactedValue
+			} else {
+				result[name] = c.redactValue(v.Field(i).Interface(), depth+1)
+			}
+		}
+		return result
+	}
+
+	return value
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that records latency and error counts of command calls
+in a metrics registry.
+
+see
+ICommandInterceptor
+
+see
+CommandMetricsRegistry
+
+Example:
+ registry := NewCommandMetricsRegistry()
+ commandSet.AddInterceptor(NewMetricsInterceptor(registry))
+*/
+type MetricsInterceptor struct {
+	registry *CommandMetricsRegistry
+}
+
+// Creates a new interceptor that records metrics in the registry.
+// Parameters:
+//  - registry *CommandMetricsRegistry
+//  the registry to record metrics in.
+// Returns *MetricsInterceptor
+func NewMetricsInterceptor(registry *CommandMetricsRegistry) *MetricsInterceptor {
+	if registry == nil {
+		panic("Metrics registry cannot be nil")
+	}
+
+	return &MetricsInterceptor{
+		registry: registry,
+	}
+}
+
+// Gets the registry used by the interceptor.
+// Returns *CommandMetricsRegistry
+// the metrics registry.
+func (c *MetricsInterceptor) Registry() *CommandMetricsRegistry {
+	return c.registry
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *MetricsInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command and records its duration and error.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or error.
+func (c *MetricsInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	start := time.Now()
+	result, err := command.Execute(correlationId, args)
+	c.registry.Record(command.Name(), time.Since(start), err)
+	return result, err
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *MetricsInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+// Version of OpenAPI specification used in generated documents.
+const OpenApiVersion = "3.1.0"
+
+/*
+Converts command sets into JSON Schemas and OpenAPI documents
+to generate documentation and external API contracts.
+
+Every command becomes a POST operation under "/<command name>" path.
+The command arguments schema is converted into JSON Schema of the request body,
+and errors are described by ErrorDescription schema.
+
+see
+CommandSet
+
+see
+validate.JsonSchemaConverter
+
+Example:
+ commandSet := NewCommandSet()
+ commandSet.AddCommand(NewCommand(
+ 	"get_dummy_by_id",
+ 	validate.NewObjectSchema().WithRequiredProperty("dummy_id", convert.String),
+ 	getDummyById,
+ ))
+
+ openApi, _ := OpenApiConverter.ToJson(commandSet, "Dummies", "1.0")
+*/
+type TOpenApiConverter struct{}
+
+var OpenApiConverter *TOpenApiConverter = &TOpenApiConverter{}
+
+type schemaCommand interface {
+	GetSchema() validate.ISchema
+}
+
+type requiredSchema interface {
+	Required() bool
+}
+
+// Converts arguments schemas of all commands in the command set into JSON Schemas.
+// Commands without schemas accept any object.
+// Parameters:
+//  - commandSet: *CommandSet
+//  the command set to convert.
+// Returns map[string]interface{}
+// JSON Schema documents by command names.
+func (c *TOpenApiConverter) ToJsonSchemas(commandSet *CommandSet) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, command := range commandSet.Commands() {
+		schema := c.argumentsSchema(command)
+		schema["$schema"] = validate.JsonSchemaDialect
+		result[command.Name()] = schema
+	}
+	return result
+}
+
+// Converts the command set into OpenAPI document with one operation per command.
+// Parameters:
+//  - commandSet: *CommandSet
+//  the command set to convert.
+//  - title: string
+//  the API title.
+//  - version: string
+//  the API version.
+// Returns map[string]interface{}
+// the OpenAPI document.
+func (c *TOpenApiConverter) ToOpenApi(commandSet *CommandSet, title string, version string) map[string]interface{} {
+	paths := map[string]interface{}{}
+	for _, command := range commandSet.Commands() {
+		paths["/"+command.Name()] = map[string]interface{}{
+			"post": c.commandOperation(command),
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": OpenApiVersion,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"ErrorDescription": c.errorSchema(),
+			},
+		},
+	}
+}
+
+// Converts the command set into JSON string with OpenAPI document.
+// Parameters:
+//  - commandSet: *CommandSet
+//  the command set to convert.
+//  - title: string
+//  the API title.
+//  - version: string
+//  the API version.
+// Returns string, error
+// the OpenAPI document as JSON string and conversion error.
+func (c *TOpenApiConverter) ToJson(commandSet *CommandSet, title string, version string) (string, error) {
+	return convert.ToJson(c.ToOpenApi(commandSet, title, version))
+}
+
+func (c *TOpenApiConverter) argumentsSchema(command ICommand) map[string]interface{} {
+	if cmd, ok := command.(schemaCommand); ok && cmd.GetSchema() != nil {
+		return validate.JsonSchemaConverter.ToJsonSchemaFragment(cmd.GetSchema())
+	}
+	return map[string]interface{}{"type": "object"}
+}
+
+func (c *TOpenApiConverter) commandOperation(command ICommand) map[string]interface{} {
+	required := false
+	if cmd, ok := command.(schemaCommand); ok {
+		if schema, ok := cmd.GetSchema().(requiredSchema); ok {
+			required = schema.Required()
+		}
+	}
+
+	errorContent := map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": map[string]interface{}{
+				"$ref": "#/components/schemas/ErrorDescription",
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"operationId": command.Name(),
+		"requestBody": map[string]interface{}{
+			"required": required,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": c.argumentsSchema(command),
+				},
+			},
+		},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Command result",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": map[string]interface{}{},
+					},
+				},
+			},
+			"400": map[string]interface{}{
+				"description": "Invalid command arguments",
+				"content":     errorContent,
+			},
+			"default": map[string]interface{}{
+				"description": "Command execution error",
+				"content":     errorContent,
+			},
+		},
+	}
+}
+
+func (c *TOpenApiConverter) errorSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"type":           map[string]interface{}{"type": "string"},
+			"category":       map[string]interface{}{"type": "string"},
+			"status":         map[string]interface{}{"type": "integer"},
+			"code":           map[string]interface{}{"type": "string"},
+			"message":        map[string]interface{}{"type": "string"},
+			"details":        map[string]interface{}{"type": "object"},
+			"correlation_id": map[string]interface{}{"type": "string"},
+			"cause":          map[string]interface{}{"type": "string"},
+			"stack_trace":    map[string]interface{}{"type": "string"},
+		},
+	}
+}
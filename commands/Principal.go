@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+)
+
+// Name of the reserved argument that carries the caller principal in command calls.
+const PrincipalParameter = "$principal"
+
+/*
+Caller identity attached to command calls and checked by AuthorizationInterceptor.
+
+see
+AuthorizationInterceptor
+
+Example:
+ principal := NewPrincipal("user1", "admin")
+ args := WithPrincipal(run.NewParametersFromTuples("dummy_id", "1"), principal)
+
+ commandSet.Execute("123", "delete_dummy", args)
+*/
+type Principal struct {
+	Id          string   `json:"id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// Creates a new principal with roles.
+// Parameters:
+//  - id: string
+//  the caller id.
+//  - roles: ...string
+//  the caller roles.
+// Returns *Principal
+func NewPrincipal(id string, roles ...string) *Principal {
+	return &Principal{
+		Id:          id,
+		Roles:       roles,
+		Permissions: []string{},
+	}
+}
+
+// Checks if the principal has the role.
+// Parameters:
+//  - role: string
+//  the role to check.
+// Returns bool
+// true if the principal has the role and false otherwise.
+func (c *Principal) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks if the principal has the permission granted directly or as a role.
+// Parameters:
+//  - permission: string
+//  the permission or role to check.
+// Returns bool
+// true if the principal has the permission and false otherwise.
+func (c *Principal) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return c.HasRole(permission)
+}
+
+// Creates a copy of command arguments with the principal attached.
+// The original arguments are not changed.
+// Parameters:
+//  - args: *run.Parameters
+//  the command arguments.
+//  - principal: *Principal
+//  the caller principal.
+// Returns *run.Parameters
+// a new arguments object with the principal.
+func WithPrincipal(args *run.Parameters, principal *Principal) *run.Parameters {
+	values := map[string]interface{}{}
+	if args != nil {
+		for key, value := range args.Value() {
+			values[key] = value
+		}
+	}
+	values[PrincipalParameter] = principal
+	return run.NewParameters(values)
+}
+
+// Gets the principal attached to command arguments.
+// Parameters:
+//  - args: *run.Parameters
+//  the command arguments.
+// Returns *Principal
+// the attached principal or nil when the call is anonymous.
+func GetPrincipal(args *run.Parameters) *Principal {
+	if args == nil {
+		return nil
+	}
+	principal, _ := args.Get(PrincipalParameter).(*Principal)
+	return principal
+}
+
+// Creates a copy of command arguments without the principal.
+// Parameters:
+//  - args: *run.Parameters
+//  the command arguments.
+// Returns *run.Parameters
+// the arguments without the principal or the original arguments when no principal is attached.
+func WithoutPrincipal(args *run.Parameters) *run.Parameters {
+	if args == nil || !args.Contains(PrincipalParameter) {
+		return args
+	}
+
+	values := map[string]interface{}{}
+	for key, value := range args.Value() {
+		if key != PrincipalParameter {
+			values[key] = value
+		}
+	}
+	return run.NewParameters(values)
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that limits the rate of command calls with a token bucket.
+When the rate limit is exceeded, commands are rejected with InvalidStateError without being executed.
+
+see
+ICommandInterceptor
+
+see
+run.RateLimiter
+
+Example:
+ limiter := run.NewRateLimiter(100, 200)
+ commandSet.AddInterceptor(NewRateLimiterInterceptor(limiter))
+*/
+type RateLimiterInterceptor struct {
+	limiter *run.RateLimiter
+}
+
+// Creates a new interceptor that uses the rate limiter.
+// Parameters:
+//  - limiter *run.RateLimiter
+//  the rate limiter to call commands through.
+// Returns *RateLimiterInterceptor
+func NewRateLimiterInterceptor(limiter *run.RateLimiter) *RateLimiterInterceptor {
+	if limiter == nil {
+		panic("Rate limiter cannot be nil")
+	}
+
+	return &RateLimiterInterceptor{
+		limiter: limiter,
+	}
+}
+
+// Gets the rate limiter used by the interceptor.
+// Returns *run.RateLimiter
+// the rate limiter.
+func (c *RateLimiterInterceptor) Limiter() *run.RateLimiter {
+	return c.limiter
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *RateLimiterInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command when the rate limit allows it.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or InvalidStateError when the rate limit is exceeded.
+func (c *RateLimiterInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	return c.limiter.ExecuteOne(correlationId, command, args)
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *RateLimiterInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
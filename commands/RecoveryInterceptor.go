@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that recovers panics in the call chain and returns them as InvocationError.
+Command already recovers panics of its action, so the interceptor is needed
+for custom ICommand implementations and interceptors added after it.
+
+see
+ICommandInterceptor
+
+Example:
+ commandSet.AddInterceptor(NewRecoveryInterceptor())
+ commandSet.AddInterceptor(NewLoggingInterceptor(sink))
+*/
+type RecoveryInterceptor struct{}
+
+// Creates a new interceptor that recovers panics.
+// Returns *RecoveryInterceptor
+func NewRecoveryInterceptor() *RecoveryInterceptor {
+	return &RecoveryInterceptor{}
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *RecoveryInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command and converts its panic into InvocationError.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or error.
+func (c *RecoveryInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = newPanicError(correlationId, command.Name(), r)
+		}
+	}()
+
+	return command.Execute(correlationId, args)
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *RecoveryInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
+
+// Converts a recovered panic into InvocationError the same way as Command does.
+func newPanicError(correlationId string, name string, r interface{}) error {
+	message := convert.StringConverter.ToString(r)
+	err := errors.NewInvocationError(
+		correlationId,
+		"EXEC_FAILED",
+		"Execution "+name+" failed: "+message,
+	).WithDetails("command", name)
+
+	if cause, ok := r.(error); ok {
+		err.WithCause(cause)
+	}
+	return err
+}
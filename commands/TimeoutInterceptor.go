@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+)
+
+/*
+Command interceptor that limits the execution time of commands.
+
+When a command doesn't complete in time, the call returns ConnectionError (NoResponse category)
+with code "COMMAND_TIMEOUT". Go can't stop the running command, so it completes in background
+and its result is discarded. Panics of the command are returned as InvocationError.
+
+Configuration parameters
+ timeout: default timeout for all commands in milliseconds (0 for no timeout)
+ timeouts:
+   <command name>: timeout for the command in milliseconds
+
+see
+ICommandInterceptor
+
+Example:
+ interceptor := NewTimeoutInterceptor(5 * time.Second)
+ interceptor.Configure(config.NewConfigParamsFromTuples(
+ 	"timeouts.generate_report", 60000,
+ ))
+
+ commandSet.AddInterceptor(interceptor)
+*/
+type TimeoutInterceptor struct {
+	timeout  time.Duration
+	timeouts map[string]time.Duration
+	lock     sync.RWMutex
+}
+
+// Creates a new interceptor with the default timeout.
+// Parameters:
+//  - timeout: time.Duration
+//  the default timeout for all commands or 0 for no timeout.
+// Returns *TimeoutInterceptor
+func NewTimeoutInterceptor(timeout time.Duration) *TimeoutInterceptor {
+	return &TimeoutInterceptor{
+		timeout:  timeout,
+		timeouts: map[string]time.Duration{},
+	}
+}
+
+// Configures the interceptor with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config: *config.ConfigParams
+//  configuration parameters to set.
+func (c *TimeoutInterceptor) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timeout = time.Duration(config.GetAsLongWithDefault("timeout", c.timeout.Milliseconds())) * time.Millisecond
+
+	timeouts := config.GetSection("timeouts")
+	for _, name := range timeouts.Keys() {
+		c.timeouts[name] = time.Duration(timeouts.GetAsLong(name)) * time.Millisecond
+	}
+}
+
+// Gets the timeout of the command.
+// Parameters:
+//  - name: string
+//  the command name.
+// Returns time.Duration
+// the command timeout or the default timeout when it is not set for the command.
+func (c *TimeoutInterceptor) Timeout(name string) time.Duration {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if timeout, ok := c.timeouts[name]; ok {
+		return timeout
+	}
+	return c.timeout
+}
+
+// Sets the default timeout for all commands.
+// Parameters:
+//  - timeout: time.Duration
+//  the default timeout or 0 for no timeout.
+func (c *TimeoutInterceptor) SetTimeout(timeout time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timeout = timeout
+}
+
+// Sets the timeout for the command.
+// Parameters:
+//  - name: string
+//  the command name.
+//  - timeout: time.Duration
+//  the command timeout or 0 for no timeout.
+func (c *TimeoutInterceptor) SetCommandTimeout(name string, timeout time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timeouts[name] = timeout
+}
+
+// Gets the name of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain.
+// Returns string
+// the name of the wrapped command.
+func (c *TimeoutInterceptor) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Executes the wrapped command and waits for the result no longer than the command timeout.
+// Parameters:
+//  - correlationId: string
+//  (optional) transaction id to trace execution through call chain.
+//  - command: ICommand
+//  the next command in the call chain that is to be executed.
+//  - args: *run.Parameters
+//  the parameters (arguments) to pass to the command for execution.
+// Returns interface{}, error
+// the command result or ConnectionError when the timeout elapsed.
+func (c *TimeoutInterceptor) Execute(correlationId string, command ICommand, args *run.Parameters) (interface{}, error) {
+	name := command.Name()
+	timeout := c.Timeout(name)
+	if timeout <= 0 {
+		return command.Execute(correlationId, args)
+	}
+
+	type executeResult struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan *executeResult, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &executeResult{err: newPanicError(correlationId, name, r)}
+			}
+		}()
+
+		result, err := command.Execute(correlationId, args)
+		done <- &executeResult{result: result, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		return r.result, r.err
+	case <-timer.C:
+		return nil, errors.NewConnectionError(
+			correlationId,
+			"COMMAND_TIMEOUT",
+			"Command "+name+" did not complete in time",
+		).WithDetails("command", name).WithDetails("timeout", timeout.Milliseconds())
+	}
+}
+
+// Validates arguments of the wrapped command.
+// Parameters:
+//  - command: ICommand
+//  the next command in the call chain to be validated against.
+//  - args: *run.Parameters
+//  the parameters (arguments) to validate.
+// Returns []*validate.ValidationResult
+// an array of ValidationResults.
+func (c *TimeoutInterceptor) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
@@ -0,0 +1,292 @@
+package refer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+)
+
+// Status of a dependency that matches exactly one component.
+const DependencyResolved = "resolved"
+
+// Status of a dependency that matches no components.
+const DependencyUnresolved = "unresolved"
+
+// Status of a dependency that matches more than one component.
+const DependencyAmbiguous = "ambiguous"
+
+/*
+Graph of dependencies between components stored in references.
+
+Nodes are registered components. Edges are named dependencies declared by components
+that implement IDependent interface, pointing to all components matching the dependency locator.
+Dependencies that match no components are marked as unresolved and dependencies that match
+several components are marked as ambiguous. The graph can be exported to JSON or to Graphviz DOT format
+to debug misconfigured containers.
+
+Lazy components are included into the graph, but their dependencies are known only after they were created.
+ScopedReferences are described together with their parent references. Dependencies are matched
+in the scope of the component first and then in parent references, in the same way as lookups.
+
+see
+IDependent
+
+see
+DependencyResolver
+
+Example:
+ graph := BuildDependencyGraph(references)
+
+ for _, edge := range graph.GetUnresolved() {
+ 	fmt.Println("Missing " + edge.Name + " dependency " + edge.Locator + " in " + edge.From)
+ }
+
+ ioutil.WriteFile("references.dot", []byte(graph.ToDot()), 0644)
+*/
+type DependencyGraph struct {
+	Nodes []*DependencyNode `json:"nodes"`
+	Edges []*DependencyEdge `json:"edges"`
+}
+
+/*
+Component node in the dependency graph.
+
+id - A unique node id in the graph
+locator - A locator the component was registered with
+type - Data type of the component or empty string for lazy components that were not created yet
+*/
+type DependencyNode struct {
+	Id      string `json:"id"`
+	Locator string `json:"locator"`
+	Type    string `json:"type"`
+}
+
+/*
+Named dependency of a component in the dependency graph.
+
+from - Id of the component node that declares the dependency
+name - A dependency name
+locator - A locator of the dependency
+to - Ids of component nodes that match the dependency locator
+status - "resolved", "unresolved" or "ambiguous" dependency status
+*/
+type DependencyEdge struct {
+	From    string   `json:"from"`
+	Name    string   `json:"name"`
+	Locator string   `json:"locator"`
+	To      []string `json:"to"`
+	Status  string   `json:"status"`
+}
+
+// Gets references registered in References and ScopedReferences without creating lazy components.
+type referencesHolder interface {
+	getReferences() []*Reference
+}
+
+// Gets layers of references from the innermost scope to the root references.
+func getGraphLayers(references IReferences) [][]*Reference {
+	layers := [][]*Reference{}
+	for references != nil {
+		layers = append(layers, getGraphReferences(references))
+
+		scope, ok := references.(*ScopedReferences)
+		if !ok {
+			break
+		}
+		references = scope.Parent()
+	}
+	return layers
+}
+
+func getGraphReferences(references IReferences) []*Reference {
+	if holder, ok := references.(referencesHolder); ok {
+		return holder.getReferences()
+	}
+
+	locators := references.GetAllLocators()
+	components := references.GetAll()
+	result := make([]*Reference, 0, len(components))
+	for index, component := range components {
+		var locator interface{}
+		if len(locators) == len(components) {
+			locator = locators[index]
+		}
+		result = append(result, NewReference(locator, component))
+	}
+	return result
+}
+
+// Builds the graph of dependencies between components stored in references.
+// Parameters:
+//  - references IReferences
+//  the references with components to describe.
+// Returns *DependencyGraph
+// the dependency graph.
+func BuildDependencyGraph(references IReferences) *DependencyGraph {
+	graph := &DependencyGraph{
+		Nodes: []*DependencyNode{},
+		Edges: []*DependencyEdge{},
+	}
+	if references == nil {
+		return graph
+	}
+
+	// Root references go first and every item keeps the layer it belongs to
+	layers := getGraphLayers(references)
+	items := []*Reference{}
+	itemLayers := []int{}
+	for layer := len(layers) - 1; layer >= 0; layer-- {
+		for _, reference := range layers[layer] {
+			items = append(items, reference)
+			itemLayers = append(itemLayers, layer)
+		}
+	}
+
+	for index, reference := range items {
+		node := &DependencyNode{
+			Id: "c" + strconv.Itoa(index),
+		}
+		if reference.Locator() != nil {
+			node.Locator = fmt.Sprint(reference.Locator())
+		}
+		if component := reference.Component(); component != nil {
+			node.Type = fmt.Sprintf("%T", component)
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	for index, reference := range items {
+		dependent, ok := reference.Component().(IDependent)
+		if !ok {
+			continue
+		}
+		resolver := dependent.GetDependencyResolver()
+		if resolver == nil {
+			continue
+		}
+
+		locators := resolver.GetLocators()
+		names := make([]string, 0, len(locators))
+		for name, locator := range locators {
+			if locator != nil {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			locator := locators[name]
+			edge := &DependencyEdge{
+				From:    graph.Nodes[index].Id,
+				Name:    name,
+				Locator: fmt.Sprint(locator),
+				To:      []string{},
+			}
+
+			// Components are searched in the component scope and then in parent scopes.
+			// Later registrations take precedence, so they go first like in lookups
+			for layer := itemLayers[index]; layer < len(layers) && len(edge.To) == 0; layer++ {
+				for dependencyIndex := len(items) - 1; dependencyIndex >= 0; dependencyIndex-- {
+					if dependencyIndex != index && itemLayers[dependencyIndex] == layer &&
+						items[dependencyIndex].Match(locator) {
+						edge.To = append(edge.To, graph.Nodes[dependencyIndex].Id)
+					}
+				}
+			}
+
+			switch len(edge.To) {
+			case 0:
+				edge.Status = DependencyUnresolved
+			case 1:
+				edge.Status = DependencyResolved
+			default:
+				edge.Status = DependencyAmbiguous
+			}
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	return graph
+}
+
+func (c *DependencyGraph) getEdges(status string) []*DependencyEdge {
+	edges := []*DependencyEdge{}
+	for _, edge := range c.Edges {
+		if edge.Status == status {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Gets dependencies that do not match any component.
+// Returns []*DependencyEdge
+// a list of unresolved dependencies.
+func (c *DependencyGraph) GetUnresolved() []*DependencyEdge {
+	return c.getEdges(DependencyUnresolved)
+}
+
+// Gets dependencies that match more than one component.
+// Returns []*DependencyEdge
+// a list of ambiguous dependencies.
+func (c *DependencyGraph) GetAmbiguous() []*DependencyEdge {
+	return c.getEdges(DependencyAmbiguous)
+}
+
+// Converts the graph into JSON string.
+// Returns string, error
+// JSON string with graph nodes and edges, and error.
+func (c *DependencyGraph) ToJson() (string, error) {
+	return convert.ToJson(c)
+}
+
+func escapeDot(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	return strings.ReplaceAll(value, "\"", "\\\"")
+}
+
+func quoteDot(value string) string {
+	return "\"" + escapeDot(value) + "\""
+}
+
+// Converts the graph into Graphviz DOT format.
+// Unresolved dependencies point to dashed red nodes and ambiguous dependencies are drawn in orange.
+// Returns string
+// the graph in DOT format.
+func (c *DependencyGraph) ToDot() string {
+	var builder strings.Builder
+	builder.WriteString("digraph references {\n")
+	builder.WriteString("  node [shape=box];\n")
+
+	for _, node := range c.Nodes {
+		label := escapeDot(node.Locator)
+		if node.Type != "" {
+			label += "\\n" + escapeDot(node.Type)
+		}
+		builder.WriteString("  " + quoteDot(node.Id) + " [label=\"" + label + "\"];\n")
+	}
+
+	for index, edge := range c.Edges {
+		label := quoteDot(edge.Name)
+		switch edge.Status {
+		case DependencyUnresolved:
+			missing := quoteDot("u" + strconv.Itoa(index))
+			builder.WriteString("  " + missing + " [label=" + quoteDot(edge.Locator) + ", style=dashed, color=red];\n")
+			builder.WriteString("  " + quoteDot(edge.From) + " -> " + missing + " [label=" + label + ", style=dashed, color=red];\n")
+		case DependencyAmbiguous:
+			for _, to := range edge.To {
+				builder.WriteString("  " + quoteDot(edge.From) + " -> " + quoteDot(to) + " [label=" + label + ", color=orange];\n")
+			}
+		default:
+			for _, to := range edge.To {
+				builder.WriteString("  " + quoteDot(edge.From) + " -> " + quoteDot(to) + " [label=" + label + "];\n")
+			}
+		}
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
@@ -18,6 +18,9 @@ Configuration parameters
   [dependency name 1]: Dependency 1 locator (descriptor)
   ...
   [dependency name N]: Dependency N locator (descriptor)
+
+Locators with glob patterns or regular expressions like "mygroup:logger:cloud*:*:1.0"
+are configured as PatternDescriptor.
 References
 References must match configured dependencies.
 
@@ -82,6 +85,14 @@ func (c *DependencyResolver) Configure(config *conf.ConfigParams) {
 			continue
 		}
 
+		if IsPatternDescriptorString(locator) {
+			pattern, err := ParsePatternDescriptorFromString(locator)
+			if err == nil {
+				c.dependencies[name] = pattern
+				continue
+			}
+		}
+
 		descriptor, err := ParseDescriptorFromString(locator)
 		if err == nil {
 			c.dependencies[name] = descriptor
@@ -110,6 +121,17 @@ func (c *DependencyResolver) Put(name string, locator interface{}) {
 	c.dependencies[name] = locator
 }
 
+// Gets locators of all dependencies declared in this resolver.
+// Returns map[string]interface{}
+// a map where key is dependency name and value is dependency locator.
+func (c *DependencyResolver) GetLocators() map[string]interface{} {
+	locators := make(map[string]interface{}, len(c.dependencies))
+	for name, locator := range c.dependencies {
+		locators[name] = locator
+	}
+	return locators
+}
+
 // Locate dependency by name
 // Parameters:
 //  - name string
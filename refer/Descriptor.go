@@ -19,6 +19,15 @@ The locator matching can be done by all or only few selected fields. The fields
 Locate all loggers (match by type and version)
 Locate persistence components for a microservice (match by group and type)
 Locate specific component by its name (match by name)
+
+The version field can also be set to a semantic version range like "^1.0", ">=1.1 <2.0" or "1.x".
+Then Match compares it to versions of other descriptors as a range, so "mygroup:persistence:*:*:^1.0"
+matches a component registered as "1.2". Plain versions are still compared as exact strings
+and ExactMatch never interprets ranges.
+
+see
+VersionRange
+
 Example
  locator1 := NewDescriptor("mygroup", "connector", "aws", "default", "1.0");
  locator2 := NewDescriptorFromString("mygroup:connector:*:*:1.0");
@@ -26,6 +35,9 @@ Example
  locator1.Match(locator2);        // Result: true
  locator1.Equal(locator2);        // Result: true
  locator1.ExactMatch(locator2);    // Result: false
+
+ locator3 := NewDescriptorFromString("mygroup:connector:*:*:^1.0");
+ locator1.Match(locator3);        // Result: true
 */
 type Descriptor struct {
 	group   string
@@ -33,6 +45,8 @@ type Descriptor struct {
 	kind    string
 	name    string
 	version string
+	// Parsed version range or nil if the version is not a range
+	versionRange *VersionRange
 }
 
 // Creates a new instance of the descriptor.
@@ -66,7 +80,13 @@ func NewDescriptor(group string, typ string, kind string, name string, version s
 		version = ""
 	}
 
-	return &Descriptor{group: group, typ: typ, kind: kind, name: name, version: version}
+	var versionRange *VersionRange
+	if isVersionRange(version) {
+		// Ranges of a wrong format just don't match any versions
+		versionRange, _ = ParseVersionRange(version)
+	}
+
+	return &Descriptor{group: group, typ: typ, kind: kind, name: name, version: version, versionRange: versionRange}
 }
 
 // Gets the component's logical group.
@@ -108,7 +128,24 @@ func matchField(field1 string, field2 string) bool {
 	return field1 == "" || field2 == "" || field1 == field2
 }
 
+func matchVersionField(descriptor1 *Descriptor, descriptor2 *Descriptor) bool {
+	version1 := descriptor1.version
+	version2 := descriptor2.version
+	if version1 == "" || version2 == "" || version1 == version2 {
+		return true
+	}
+
+	if isVersionRange(version1) && !isVersionRange(version2) {
+		return descriptor1.versionRange != nil && descriptor1.versionRange.Contains(version2)
+	}
+	if isVersionRange(version2) && !isVersionRange(version1) {
+		return descriptor2.versionRange != nil && descriptor2.versionRange.Contains(version1)
+	}
+	return false
+}
+
 // Partially matches this descriptor to another descriptor. Fields that contain "*" or null are excluded from the match.
+// Versions set to semantic version ranges are matched against versions of the other descriptor.
 // see
 // exactMatch
 // Parameters:
@@ -121,7 +158,7 @@ func (c *Descriptor) Match(descriptor *Descriptor) bool {
 		matchField(c.typ, descriptor.Type()) &&
 		matchField(c.kind, descriptor.Kind()) &&
 		matchField(c.name, descriptor.Name()) &&
-		matchField(c.version, descriptor.Version())
+		matchVersionField(c, descriptor)
 }
 
 func exactMatchField(field1 string, field2 string) bool {
@@ -158,7 +195,8 @@ func (c *Descriptor) IsComplete() bool {
 		c.name != "" && c.version != ""
 }
 
-// Compares this descriptor to a value. If value is a Descriptor it tries to match them, otherwise the method returns false.
+// Compares this descriptor to a value. If value is a Descriptor it tries to match them,
+// if value is a PatternDescriptor it matches this descriptor against the patterns, otherwise the method returns false.
 // see
 // Match
 // Parameters:
@@ -171,6 +209,10 @@ func (c *Descriptor) Equals(value interface{}) bool {
 	if ok {
 		return c.Match(descriptor)
 	}
+	pattern, ok := value.(*PatternDescriptor)
+	if ok && pattern != nil {
+		return pattern.Match(c)
+	}
 	return false
 }
 
@@ -0,0 +1,36 @@
+package refer
+
+/*
+Interface for components that declare their dependencies using DependencyResolver.
+
+The declared dependencies are used by LifecycleManager to set references, open and close components in the right order.
+
+see
+DependencyResolver
+
+see
+LifecycleManager
+
+Example
+ type MyController struct {
+ 	dependencyResolver *DependencyResolver
+ }
+
+ func NewMyController() *MyController {
+ 	return &MyController{
+ 		dependencyResolver: NewDependencyResolverFromTuples(
+ 			"persistence", NewDescriptor("mygroup", "persistence", "*", "*", "1.0"),
+ 		),
+ 	}
+ }
+
+ func (c *MyController) GetDependencyResolver() *DependencyResolver {
+ 	return c.dependencyResolver
+ }
+*/
+type IDependent interface {
+	// Gets the resolver with declared component dependencies.
+	// Returns *DependencyResolver
+	// the dependency resolver.
+	GetDependencyResolver() *DependencyResolver
+}
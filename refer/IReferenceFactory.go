@@ -0,0 +1,50 @@
+package refer
+
+/*
+Interface for factories that create components registered in References on demand.
+
+see
+References
+
+see
+ReferenceScope
+
+Example:
+ type MyPersistenceFactory struct{}
+
+ func (c *MyPersistenceFactory) Create(locator interface{}) (interface{}, error) {
+ 	return NewMyMongoDbPersistence(), nil
+ }
+
+ references.PutFactory(
+ 	NewDescriptor("mygroup", "persistence", "mongodb", "default", "1.0"),
+ 	&MyPersistenceFactory{}, Singleton,
+ )
+*/
+type IReferenceFactory interface {
+	// Creates a component registered under the locator.
+	// Parameters:
+	//  - locator interface{}
+	//  the locator the factory was registered with.
+	// Returns interface{}, error
+	// the created component and error.
+	Create(locator interface{}) (interface{}, error)
+}
+
+// Function that implements IReferenceFactory interface.
+//
+// Example:
+//  references.PutFactory("MyComponent", ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+//  	return NewMyComponent(), nil
+//  }), Transient)
+type ReferenceFactoryFunc func(locator interface{}) (interface{}, error)
+
+// Creates a component by calling the function.
+// Parameters:
+//  - locator interface{}
+//  the locator the factory was registered with.
+// Returns interface{}, error
+// the created component and error.
+func (c ReferenceFactoryFunc) Create(locator interface{}) (interface{}, error) {
+	return c(locator)
+}
@@ -0,0 +1,63 @@
+package refer
+
+/*
+Interface for listener objects that receive notifications when components are added to or removed from References.
+
+It allows hot-pluggable components to rewire themselves when their dependencies change without restarting the container.
+Listeners are called synchronously after the change is made, so they can use the references to locate other components.
+
+see
+References
+
+see
+ReferenceChange
+
+Example:
+ type MyController struct {
+ 	logger ILogger
+ }
+
+ func (c *MyController) OnReferenceChanged(change ReferenceChange, locator interface{}, component interface{}) {
+ 	if logger, ok := component.(ILogger); ok && change == ReferenceAdded {
+ 		c.logger = logger
+ 	}
+ }
+
+ references.AddListener(controller)
+ references.Put(NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger)
+*/
+type IReferenceListener interface {
+	// A method called when a component is added to or removed from references.
+	// Parameters:
+	//  - change ReferenceChange
+	//  the kind of the change.
+	//  - locator interface{}
+	//  the locator the component was registered with.
+	//  - component interface{}
+	//  the added or removed component. It is nil for lazy components that were not created yet.
+	OnReferenceChanged(change ReferenceChange, locator interface{}, component interface{})
+}
+
+// Function that implements IReferenceListener interface.
+// Functions cannot be compared, so to be able to remove the listener later it shall be added by pointer.
+//
+// Example:
+//  listener := ReferenceListenerFunc(func(change ReferenceChange, locator interface{}, component interface{}) {
+//  	fmt.Println("Changed reference " + fmt.Sprint(locator))
+//  })
+//  references.AddListener(&listener)
+//  ...
+//  references.RemoveListener(&listener)
+type ReferenceListenerFunc func(change ReferenceChange, locator interface{}, component interface{})
+
+// Handles the change by calling the function.
+// Parameters:
+//  - change ReferenceChange
+//  the kind of the change.
+//  - locator interface{}
+//  the locator the component was registered with.
+//  - component interface{}
+//  the added or removed component.
+func (c ReferenceListenerFunc) OnReferenceChanged(change ReferenceChange, locator interface{}, component interface{}) {
+	c(change, locator, component)
+}
@@ -0,0 +1,315 @@
+package refer
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+)
+
+/*
+Manages lifecycle of components stored in references.
+
+The manager builds a dependency graph from dependencies declared by components that implement IDependent interface.
+Then it sets references and opens components in topological order, so each component is opened after its dependencies.
+Components are closed in the reverse order. If one of components fails to open,
+all previously opened components are closed back. Circular dependencies are reported as ReferenceError.
+
+see
+IDependent
+
+see
+DependencyResolver
+
+see
+Referencer
+
+see
+Opener
+
+see
+Closer
+
+Example:
+ references := NewReferencesFromTuples(
+ 	NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), persistence,
+ 	NewDescriptor("mygroup", "controller", "default", "default", "1.0"), controller,
+ )
+
+ manager := NewLifecycleManager(references)
+ err := manager.Open("123")
+ ...
+ err = manager.Close("123")
+*/
+type LifecycleManager struct {
+	references IReferences
+	opened     []interface{}
+	lock       sync.Mutex
+}
+
+// Creates a new instance of the lifecycle manager.
+// Parameters:
+//  - references IReferences
+//  the references with components to manage.
+// Returns *LifecycleManager
+func NewLifecycleManager(references IReferences) *LifecycleManager {
+	if references == nil {
+		panic("References cannot be nil")
+	}
+
+	return &LifecycleManager{
+		references: references,
+	}
+}
+
+// Gets the managed component references.
+// Returns IReferences
+// the component references.
+func (c *LifecycleManager) References() IReferences {
+	return c.references
+}
+
+func indexOfComponent(components []interface{}, component interface{}) int {
+	for index, item := range components {
+		if isSameComponent(item, component) {
+			return index
+		}
+	}
+	return -1
+}
+
+func isSameComponent(component1 interface{}, component2 interface{}) bool {
+	if component1 == nil || component2 == nil {
+		return false
+	}
+
+	typ := reflect.TypeOf(component1)
+	if typ != reflect.TypeOf(component2) {
+		return false
+	}
+	if typ.Comparable() {
+		return component1 == component2
+	}
+
+	// Maps and slices are the same component when they share the same data
+	switch typ.Kind() {
+	case reflect.Map, reflect.Slice:
+		value1 := reflect.ValueOf(component1)
+		value2 := reflect.ValueOf(component2)
+		return value1.Pointer() == value2.Pointer() && value1.Len() == value2.Len()
+	}
+	return false
+}
+
+func (c *LifecycleManager) getDependencies(components []interface{}) [][]int {
+	dependencies := make([][]int, len(components))
+
+	for index, component := range components {
+		dependent, ok := component.(IDependent)
+		if !ok {
+			continue
+		}
+		resolver := dependent.GetDependencyResolver()
+		if resolver == nil {
+			continue
+		}
+
+		for _, locator := range resolver.GetLocators() {
+			if locator == nil {
+				continue
+			}
+
+			for _, dependency := range c.references.GetOptional(locator) {
+				dependencyIndex := indexOfComponent(components, dependency)
+				if dependencyIndex < 0 || dependencyIndex == index {
+					continue
+				}
+
+				found := false
+				for _, existingIndex := range dependencies[index] {
+					found = found || existingIndex == dependencyIndex
+				}
+				if !found {
+					dependencies[index] = append(dependencies[index], dependencyIndex)
+				}
+			}
+		}
+
+		sort.Ints(dependencies[index])
+	}
+
+	return dependencies
+}
+
+func findDependencyCycle(dependencies [][]int, done []bool) []int {
+	start := -1
+	for index := range dependencies {
+		if !done[index] {
+			start = index
+			break
+		}
+	}
+
+	// Every remaining component waits for another remaining one, so following them leads into a cycle
+	path := []int{}
+	positions := map[int]int{}
+	current := start
+	for {
+		if position, ok := positions[current]; ok {
+			return append(path[position:], current)
+		}
+		positions[current] = len(path)
+		path = append(path, current)
+
+		for _, dependencyIndex := range dependencies[current] {
+			if !done[dependencyIndex] {
+				current = dependencyIndex
+				break
+			}
+		}
+	}
+}
+
+// Gets components in the order they shall be opened.
+// Dependencies always go before components that depend on them,
+// otherwise components keep the order they were registered in references.
+// throws
+// a ReferenceError when components have circular dependencies.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns []interface{}, error
+// ordered list of components and error.
+func (c *LifecycleManager) GetOpenOrder(correlationId string) ([]interface{}, error) {
+	components := c.references.GetAll()
+	dependencies := c.getDependencies(components)
+
+	order := make([]interface{}, 0, len(components))
+	done := make([]bool, len(components))
+
+	for len(order) < len(components) {
+		next := -1
+		for index := range components {
+			if done[index] {
+				continue
+			}
+
+			ready := true
+			for _, dependencyIndex := range dependencies[index] {
+				ready = ready && done[dependencyIndex]
+			}
+			if ready {
+				next = index
+				break
+			}
+		}
+
+		if next < 0 {
+			cycle := findDependencyCycle(dependencies, done)
+			locators := c.references.GetAllLocators()
+			cycleLocators := make([]interface{}, len(cycle))
+			for index, componentIndex := range cycle {
+				if len(locators) == len(components) {
+					cycleLocators[index] = locators[componentIndex]
+				} else {
+					cycleLocators[index] = components[componentIndex]
+				}
+			}
+			return nil, NewReferenceCycleError(correlationId, cycleLocators)
+		}
+
+		done[next] = true
+		order = append(order, components[next])
+	}
+
+	return order, nil
+}
+
+// Checks if the components are opened.
+// Returns bool
+// true if the components have been opened and false otherwise.
+func (c *LifecycleManager) IsOpen() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.opened != nil
+}
+
+// Sets references and opens all components in the order of their dependencies.
+// If a component fails to open, all previously opened components are closed in the reverse order.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns error
+func (c *LifecycleManager) Open(correlationId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.opened != nil {
+		return nil
+	}
+
+	components, err := c.GetOpenOrder(correlationId)
+	if err != nil {
+		return err
+	}
+
+	for _, component := range components {
+		Referencer.SetReferencesForOne(c.references, component)
+	}
+
+	opened := make([]interface{}, 0, len(components))
+	for _, component := range components {
+		err = run.Opener.OpenOne(correlationId, component)
+		if err != nil {
+			closeComponents(correlationId, opened)
+			unsetReferences(components)
+			return err
+		}
+		opened = append(opened, component)
+	}
+
+	c.opened = opened
+	return nil
+}
+
+// Closes components in the reverse order. All components are closed even if some of them fail.
+// The first occured error is returned.
+func closeComponents(correlationId string, components []interface{}) error {
+	var firstErr error
+	for index := len(components) - 1; index >= 0; index-- {
+		err := run.Closer.CloseOne(correlationId, components[index])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Unsets references in components in the reverse order.
+func unsetReferences(components []interface{}) {
+	for index := len(components) - 1; index >= 0; index-- {
+		Referencer.UnsetReferencesForOne(components[index])
+	}
+}
+
+// Closes all opened components in the reverse order and unsets their references.
+// All components are closed even if some of them fail. The first occured error is returned.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns error
+func (c *LifecycleManager) Close(correlationId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.opened == nil {
+		return nil
+	}
+
+	err := closeComponents(correlationId, c.opened)
+	unsetReferences(c.opened)
+	c.opened = nil
+	return err
+}
@@ -0,0 +1,305 @@
+package refer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Locator that matches descriptors by patterns in their fields.
+
+Each field of the pattern descriptor can be set to:
+
+ *              - any value, the same as in Descriptor
+ cloud*         - glob pattern where "*" matches any characters, "?" matches one character
+                  and "[abc]" or "[a-z]" match one character from the set ("[!abc]" excludes the set)
+ /^tenant-\d+$/ - regular expression enclosed in slashes (it cannot contain colons)
+ default        - exact value
+
+The version field also accepts semantic version ranges supported by Descriptor.
+
+Pattern descriptors are used as locators to find components registered with regular descriptors.
+They can be passed to References and DependencyResolver or set in "dependencies" configuration section.
+
+see
+Descriptor
+
+see
+VersionRange
+
+Example:
+ locator, _ := NewPatternDescriptor("*", "logger", "cloud*", "*", "1.0")
+
+ locator.Match(NewDescriptor("pip-services", "logger", "cloudwatch", "default", "1.0"));  // Result: true
+ locator.Match(NewDescriptor("pip-services", "logger", "console", "default", "1.0"));     // Result: false
+
+ loggers := references.GetOptional(locator)
+*/
+type PatternDescriptor struct {
+	fields       [5]string
+	patterns     [5]*regexp.Regexp
+	versionRange *VersionRange
+}
+
+// Checks if the field is a regular expression enclosed in slashes.
+func isRegexpField(value string) bool {
+	return len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/")
+}
+
+// Checks if the field is a glob pattern or a regular expression.
+func isPatternField(value string) bool {
+	return isRegexpField(value) || (value != "*" && strings.ContainsAny(value, "*?["))
+}
+
+// Converts a glob pattern into an equivalent regular expression.
+func globToRegexp(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("^")
+
+	for index := 0; index < len(pattern); index++ {
+		char := pattern[index]
+		switch char {
+		case '*':
+			builder.WriteString(".*")
+		case '?':
+			builder.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[index+1:], ']')
+			if end < 0 {
+				builder.WriteString(regexp.QuoteMeta(string(char)))
+				continue
+			}
+			set := pattern[index+1 : index+1+end]
+			if strings.HasPrefix(set, "!") {
+				set = "^" + set[1:]
+			}
+			builder.WriteString("[" + strings.ReplaceAll(set, "\\", "\\\\") + "]")
+			index += end + 1
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	builder.WriteString("$")
+	return builder.String()
+}
+
+// Creates a new instance of the pattern descriptor.
+// throws
+// a ConfigError if one of the patterns is of a wrong format.
+// Parameters:
+//  - group string
+//  a logical component group pattern
+//  - type string
+//  a logical component type pattern
+//  - kind string
+//  a component implementation type pattern
+//  - name string
+//  a unique component name pattern
+//  - version string
+//  a component implementation version pattern or range
+// Returns *PatternDescriptor, error
+// a newly created PatternDescriptor and error.
+func NewPatternDescriptor(group string, typ string, kind string, name string, version string) (*PatternDescriptor, error) {
+	c := &PatternDescriptor{
+		fields: [5]string{group, typ, kind, name, version},
+	}
+
+	for index, field := range c.fields {
+		if field == "*" {
+			field = ""
+			c.fields[index] = field
+		}
+
+		if index == 4 && !isRegexpField(field) && isVersionRange(field) {
+			versionRange, err := ParseVersionRange(field)
+			if err != nil {
+				return nil, err
+			}
+			c.versionRange = versionRange
+			continue
+		}
+
+		if !isPatternField(field) {
+			continue
+		}
+
+		expression := ""
+		if isRegexpField(field) {
+			expression = field[1 : len(field)-1]
+		} else {
+			expression = globToRegexp(field)
+		}
+
+		pattern, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, errors.NewConfigError("", "BAD_PATTERN", "Pattern "+field+" is in wrong format").
+				WithDetails("pattern", field).WithCause(err)
+		}
+		c.patterns[index] = pattern
+	}
+
+	return c, nil
+}
+
+// Gets the component's logical group pattern.
+// Returns string
+// the component's logical group pattern.
+func (c *PatternDescriptor) Group() string {
+	return c.fields[0]
+}
+
+// Gets the component's logical type pattern.
+// Returns string
+// the component's logical type pattern.
+func (c *PatternDescriptor) Type() string {
+	return c.fields[1]
+}
+
+// Gets the component's implementation type pattern.
+// Returns string
+// the component's implementation type pattern.
+func (c *PatternDescriptor) Kind() string {
+	return c.fields[2]
+}
+
+// Gets the unique component's name pattern.
+// Returns string
+// the unique component's name pattern.
+func (c *PatternDescriptor) Name() string {
+	return c.fields[3]
+}
+
+// Gets the component's implementation version pattern.
+// Returns string
+// the component's implementation version pattern.
+func (c *PatternDescriptor) Version() string {
+	return c.fields[4]
+}
+
+// Gets fields that can be matched only by equal values and "" for patterns and wildcards.
+// They are used to find candidates in the reference index.
+func (c *PatternDescriptor) exactFields() [5]string {
+	fields := c.fields
+	for index := range fields {
+		if c.patterns[index] != nil || (index == 4 && c.versionRange != nil) {
+			fields[index] = ""
+		}
+	}
+	return fields
+}
+
+func (c *PatternDescriptor) matchField(index int, value string) bool {
+	field := c.fields[index]
+	if field == "" || value == "" || field == value {
+		return true
+	}
+
+	if c.patterns[index] != nil {
+		return c.patterns[index].MatchString(value)
+	}
+	if index == 4 && c.versionRange != nil {
+		return c.versionRange.Contains(value)
+	}
+	return false
+}
+
+// Matches a descriptor against the patterns in this descriptor.
+// Fields that contain "*" or empty values in either descriptor are excluded from the match.
+// Parameters:
+//  - descriptor *Descriptor
+//  the descriptor to match this one against.
+// Returns bool
+// true if the descriptor matches and false otherwise
+func (c *PatternDescriptor) Match(descriptor *Descriptor) bool {
+	if descriptor == nil {
+		return false
+	}
+
+	for index, value := range descriptorFields(descriptor) {
+		if !c.matchField(index, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compares this pattern descriptor to a value.
+// Descriptors are matched against the patterns, pattern descriptors are equal when they have the same patterns.
+// see
+// Match
+// Parameters:
+//  - value interface{}
+//  the value to match against this descriptor.
+// Returns bool
+// true if the value is matching descriptor and false otherwise.
+func (c *PatternDescriptor) Equals(value interface{}) bool {
+	if descriptor, ok := value.(*Descriptor); ok {
+		return c.Match(descriptor)
+	}
+	if pattern, ok := value.(*PatternDescriptor); ok && pattern != nil {
+		return c.fields == pattern.fields
+	}
+	return false
+}
+
+// Gets a string representation of the object. The result is a colon-separated list of descriptor fields as "mygroup:logger:cloud*:*:1.0"
+// Returns string
+// a string representation of the object.
+func (c *PatternDescriptor) String() string {
+	fields := make([]string, len(c.fields))
+	for index, field := range c.fields {
+		if field == "" {
+			field = "*"
+		}
+		fields[index] = field
+	}
+	return strings.Join(fields, ":")
+}
+
+// Checks if a colon-separated descriptor string contains patterns
+// in group, type, kind or name fields or regular expressions in any field.
+// Version fields like "1.*" are treated by Descriptor as version ranges.
+// Parameters:
+//  - value string
+//  colon-separated descriptor fields.
+// Returns bool
+// true if the value shall be parsed as a pattern descriptor and false otherwise.
+func IsPatternDescriptorString(value string) bool {
+	tokens := strings.Split(value, ":")
+	if len(tokens) != 5 {
+		return false
+	}
+
+	for index, token := range tokens {
+		token = strings.TrimSpace(token)
+		if isRegexpField(token) || (index < 4 && isPatternField(token)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parses colon-separated list of descriptor field patterns and returns them as a PatternDescriptor.
+// throws
+// a ConfigError if the descriptor string or one of the patterns is of a wrong format.
+// Parameters:
+//  - value string
+//  colon-separated descriptor field patterns to initialize PatternDescriptor.
+// Returns *PatternDescriptor, error
+// a newly created PatternDescriptor and error.
+func ParsePatternDescriptorFromString(value string) (*PatternDescriptor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	tokens := strings.Split(value, ":")
+	if len(tokens) != 5 {
+		return nil, errors.NewConfigError("", "BAD_DESCRIPTOR", "Descriptor "+value+" is in wrong format")
+	}
+
+	return NewPatternDescriptor(strings.TrimSpace(tokens[0]), strings.TrimSpace(tokens[1]),
+		strings.TrimSpace(tokens[2]), strings.TrimSpace(tokens[3]), strings.TrimSpace(tokens[4]))
+}
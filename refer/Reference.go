@@ -1,13 +1,31 @@
 package refer
 
-import "github.com/pip-services3-go/pip-services3-commons-go/data"
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/data"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
 
 /*
 Contains a reference to a component and locator to find it. It is used by References to store registered component references.
+
+Lazy references hold a factory instead of a component. The component is created when the reference is resolved.
 */
 type Reference struct {
 	locator   interface{}
 	component interface{}
+	factory   IReferenceFactory
+	scope     ReferenceScope
+	lock      sync.RWMutex
+	// Serializes creation of singleton components
+	createLock sync.Mutex
+	// Goroutines that are creating the component at the moment
+	creators map[uint64]bool
 }
 
 // Create a new instance of the reference object and assigns its values.
@@ -27,13 +45,157 @@ func NewReference(locator interface{}, component interface{}) *Reference {
 	}
 }
 
+// Create a new instance of the lazy reference that creates its component by the factory.
+// Parameters:
+//  - locator interface{}
+//  a locator to find the reference.
+//  - factory IReferenceFactory
+//  a factory to create the component.
+//  - scope ReferenceScope
+//  Singleton to create the component once or Transient to create it on every resolution.
+// Returns *Reference
+func NewLazyReference(locator interface{}, factory IReferenceFactory, scope ReferenceScope) *Reference {
+	if factory == nil {
+		panic("Factory cannot be null")
+	}
+
+	return &Reference{
+		locator: locator,
+		factory: factory,
+		scope:   scope,
+	}
+}
+
 // Gets the stored component reference.
+// Lazy references return their singleton component only after it was created, otherwise nil.
 // Returns any
 // the component's references.
 func (c *Reference) Component() interface{} {
+	if c.factory == nil {
+		return c.component
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
 	return c.component
 }
 
+// Checks if the component is created by a factory.
+// Returns bool
+// true if the reference is lazy and false otherwise.
+func (c *Reference) IsLazy() bool {
+	return c.factory != nil
+}
+
+// Gets the component and creates it by the factory if the reference is lazy.
+// Singleton components are created only once even when the reference is resolved concurrently.
+// throws
+// an InvocationError when the factory fails to create the component
+// or a ReferenceError when the factory resolves its own reference.
+// Returns interface{}, error
+// the component and error.
+func (c *Reference) Resolve() (interface{}, error) {
+	if c.factory == nil {
+		return c.component, nil
+	}
+
+	if c.scope == Transient {
+		return c.createOnce(goroutineId())
+	}
+
+	if component := c.Component(); component != nil {
+		return component, nil
+	}
+
+	// Detect factories that resolve their own reference before waiting for the lock they hold
+	creator := goroutineId()
+	if c.isCreating(creator) {
+		return nil, c.cycleError()
+	}
+
+	c.createLock.Lock()
+	defer c.createLock.Unlock()
+
+	// Check if the component was created while waiting for the lock
+	if component := c.Component(); component != nil {
+		return component, nil
+	}
+
+	component, err := c.createOnce(creator)
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	c.component = component
+	c.lock.Unlock()
+
+	return component, nil
+}
+
+func (c *Reference) isCreating(creator uint64) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.creators[creator]
+}
+
+func (c *Reference) cycleError() error {
+	return NewReferenceCycleError("", []interface{}{c.locator, c.locator})
+}
+
+// Creates the component and marks the goroutine as a creator while the factory runs.
+func (c *Reference) createOnce(creator uint64) (interface{}, error) {
+	c.lock.Lock()
+	if c.creators[creator] {
+		c.lock.Unlock()
+		return nil, c.cycleError()
+	}
+	if c.creators == nil {
+		c.creators = map[uint64]bool{}
+	}
+	c.creators[creator] = true
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		delete(c.creators, creator)
+		c.lock.Unlock()
+	}()
+
+	return c.create()
+}
+
+func (c *Reference) create() (component interface{}, err error) {
+	// Intercepting factory panics
+	defer func() {
+		if r := recover(); r != nil {
+			component = nil
+			err = NewReferenceCreationError("", c.locator, errors.NewError(convert.StringConverter.ToString(r)))
+		}
+	}()
+
+	component, err = c.factory.Create(c.locator)
+	if err != nil {
+		return nil, NewReferenceCreationError("", c.locator, err)
+	}
+	if component == nil {
+		return nil, NewReferenceCreationError("", c.locator, errors.NewError("Factory returned nil component"))
+	}
+	return component, nil
+}
+
+// Gets id of the current goroutine from its stack header "goroutine <id> [...]".
+func goroutineId() uint64 {
+	var buffer [64]byte
+	header := strings.TrimPrefix(string(buffer[:runtime.Stack(buffer[:], false)]), "goroutine ")
+	if index := strings.IndexByte(header, ' '); index > 0 {
+		header = header[:index]
+	}
+	id, _ := strconv.ParseUint(header, 10, 64)
+	return id
+}
+
 // Gets the stored component locator.
 // Returns any
 // the component's locator.
@@ -57,7 +219,7 @@ func (c *Reference) Match(locator interface{}) bool {
 	}
 
 	// Locate by direct reference matching
-	if c.component == locator {
+	if component := c.Component(); component != nil && component == locator {
 		return true
 	}
 
@@ -0,0 +1,15 @@
+package refer
+
+/*
+Defines kinds of changes in References reported to IReferenceListener.
+
+ReferenceAdded - a component was put into references.
+
+ReferenceRemoved - a component was removed from references.
+*/
+type ReferenceChange int
+
+const (
+	ReferenceAdded ReferenceChange = iota
+	ReferenceRemoved
+)
@@ -5,6 +5,7 @@ Error when required component dependency cannot be found.
 */
 import (
 	"fmt"
+	"strings"
 
 	"github.com/pip-services3-go/pip-services3-commons-go/errors"
 )
@@ -21,3 +22,66 @@ func NewReferenceError(correlationId string, locator interface{}) *errors.Applic
 	e.WithDetails("locator", locator)
 	return e
 }
+
+// Creates an error instance for circular dependencies between components.
+// The error has the same code as other reference errors and keeps the cycle path in "cycle" details.
+// Parameters:
+//  - correlationId string
+//  - locators []interface{}
+//  the locators of components that form the cycle.
+// Returns *errors.ApplicationError
+func NewReferenceCycleError(correlationId string, locators []interface{}) *errors.ApplicationError {
+	cycle := make([]string, len(locators))
+	for index, locator := range locators {
+		cycle[index] = fmt.Sprintf("%v", locator)
+	}
+
+	var locator interface{}
+	if len(locators) > 0 {
+		locator = locators[0]
+	}
+
+	e := NewReferenceError(correlationId, locator)
+	e.Message = "Found circular references between " + strings.Join(cycle, " -> ")
+	e.WithDetails("cycle", cycle)
+	return e
+}
+
+// Creates an error instance for failures to create a component by its factory.
+// Parameters:
+//  - correlationId string
+//  - locator interface{}
+//  the locator the component factory was registered with.
+//  - cause error
+//  the error returned by the factory.
+// Returns *errors.ApplicationError
+func NewReferenceCreationError(correlationId string, locator interface{}, cause error) *errors.ApplicationError {
+	message := fmt.Sprintf("Failed to create component for %v", locator)
+	e := errors.NewInvocationError(correlationId, "REF_CREATE_FAILED", message)
+	e.WithDetails("locator", locator)
+	if cause != nil {
+		e.WithCause(cause)
+	}
+	return e
+}
+
+// Creates an error instance for components that do not have the expected type.
+// The error has the same code as other reference errors and keeps the types in "expected_type" and "actual_type" details.
+// Parameters:
+//  - correlationId string
+//  - locator interface{}
+//  the locator the component was found by.
+//  - expectedType string
+//  the name of the expected component type.
+//  - component interface{}
+//  the found component.
+// Returns *errors.ApplicationError
+func NewReferenceTypeError(correlationId string, locator interface{}, expectedType string, component interface{}) *errors.ApplicationError {
+	actualType := fmt.Sprintf("%T", component)
+	message := fmt.Sprintf("Reference to %v has type %s but %s was expected", locator, actualType, expectedType)
+	e := NewReferenceError(correlationId, locator)
+	e.Message = message
+	e.WithDetails("expected_type", expectedType)
+	e.WithDetails("actual_type", actualType)
+	return e
+}
@@ -0,0 +1,185 @@
+package refer
+
+/*
+Index of component references keyed by descriptor fields.
+It is used by References to avoid linear scans when components are located by descriptors.
+
+References registered with non-descriptor locators are not indexed and are always checked.
+Descriptor fields set to "*" or to version ranges are kept in separate per-field lists,
+since they can match different values.
+*/
+type referenceIndex struct {
+	sequence  uint64
+	values    [5]map[string][]*indexedReference
+	wildcards [5][]*indexedReference
+	unindexed []*indexedReference
+}
+
+type indexedReference struct {
+	sequence  uint64
+	reference *Reference
+}
+
+func newReferenceIndex() *referenceIndex {
+	c := &referenceIndex{}
+	for index := range c.values {
+		c.values[index] = map[string][]*indexedReference{}
+	}
+	return c
+}
+
+func descriptorFields(descriptor *Descriptor) [5]string {
+	return [5]string{
+		descriptor.Group(),
+		descriptor.Type(),
+		descriptor.Kind(),
+		descriptor.Name(),
+		descriptor.Version(),
+	}
+}
+
+// Checks if the descriptor field can only be matched by equal values.
+func isIndexedField(index int, value string) bool {
+	if value == "" {
+		return false
+	}
+	if index == 4 && isVersionRange(value) {
+		return false
+	}
+	return true
+}
+
+// Gets the descriptor the reference can be indexed by.
+// References that can also be matched by their component are not indexed.
+func indexedDescriptor(reference *Reference) (*Descriptor, bool) {
+	if _, ok := reference.Component().(*Descriptor); ok && !reference.IsLazy() {
+		return nil, false
+	}
+	descriptor, ok := reference.Locator().(*Descriptor)
+	return descriptor, ok && descriptor != nil
+}
+
+// Adds a reference into the index.
+// Parameters:
+//  - reference *Reference
+//  the reference to be added.
+func (c *referenceIndex) add(reference *Reference) {
+	entry := &indexedReference{sequence: c.sequence, reference: reference}
+	c.sequence++
+
+	descriptor, ok := indexedDescriptor(reference)
+	if !ok {
+		c.unindexed = append(c.unindexed, entry)
+		return
+	}
+
+	for index, value := range descriptorFields(descriptor) {
+		if !isIndexedField(index, value) {
+			c.wildcards[index] = append(c.wildcards[index], entry)
+		} else {
+			c.values[index][value] = append(c.values[index][value], entry)
+		}
+	}
+}
+
+func removeIndexedReference(entries []*indexedReference, reference *Reference) []*indexedReference {
+	for index, entry := range entries {
+		if entry.reference == reference {
+			return append(entries[:index], entries[index+1:]...)
+		}
+	}
+	return entries
+}
+
+// Removes a reference from the index.
+// Parameters:
+//  - reference *Reference
+//  the reference to be removed.
+func (c *referenceIndex) remove(reference *Reference) {
+	descriptor, ok := indexedDescriptor(reference)
+	if !ok {
+		c.unindexed = removeIndexedReference(c.unindexed, reference)
+		return
+	}
+
+	for index, value := range descriptorFields(descriptor) {
+		if !isIndexedField(index, value) {
+			c.wildcards[index] = removeIndexedReference(c.wildcards[index], reference)
+			continue
+		}
+
+		entries := removeIndexedReference(c.values[index][value], reference)
+		if len(entries) == 0 {
+			delete(c.values[index], value)
+		} else {
+			c.values[index][value] = entries
+		}
+	}
+}
+
+// Finds references that match the descriptor locator.
+// The index picks the most selective descriptor field and checks only references
+// registered with that field value, with "*" or a version range in that field or with non-descriptor locators.
+// Parameters:
+//  - fields [5]string
+//  the locator fields that can be matched only by equal values.
+//  - locator interface{}
+//  the locator to match candidate references.
+// Returns []*Reference, bool
+// matching references starting from the last registered one,
+// and false if the locator has no fields to use the index.
+func (c *referenceIndex) find(fields [5]string, locator interface{}) ([]*Reference, bool) {
+	var candidates [][]*indexedReference
+	size := -1
+
+	for index, value := range fields {
+		if !isIndexedField(index, value) {
+			continue
+		}
+
+		values := c.values[index][value]
+		wildcards := c.wildcards[index]
+		if size < 0 || len(values)+len(wildcards) < size {
+			candidates = [][]*indexedReference{values, wildcards}
+			size = len(values) + len(wildcards)
+		}
+	}
+
+	if size < 0 {
+		return nil, false
+	}
+	candidates = append(candidates, c.unindexed)
+
+	// Merge candidate lists in reverse registration order
+	positions := make([]int, len(candidates))
+	for index, entries := range candidates {
+		positions[index] = len(entries) - 1
+	}
+
+	references := make([]*Reference, 0, 2)
+	for {
+		next := -1
+		for index, entries := range candidates {
+			position := positions[index]
+			if position < 0 {
+				continue
+			}
+			if next < 0 || entries[position].sequence > candidates[next][positions[next]].sequence {
+				next = index
+			}
+		}
+
+		if next < 0 {
+			break
+		}
+
+		entry := candidates[next][positions[next]]
+		positions[next]--
+
+		if entry.reference.Match(locator) {
+			references = append(references, entry.reference)
+		}
+	}
+
+	return references, true
+}
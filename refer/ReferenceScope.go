@@ -0,0 +1,15 @@
+package refer
+
+/*
+Defines how components created by IReferenceFactory are shared.
+
+Singleton - the component is created on the first lookup and the same instance is returned afterwards.
+
+Transient - a new component is created on every lookup.
+*/
+type ReferenceScope int
+
+const (
+	Singleton ReferenceScope = iota
+	Transient
+)
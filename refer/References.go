@@ -1,8 +1,26 @@
 package refer
 
+import (
+	"reflect"
+	"sync"
+)
+
 /*
 The most basic implementation of IReferences to store and locate component references.
 
+The references are safe for concurrent use: lookups are performed under a shared read lock
+and can run in parallel, while Put, Remove and RemoveAll take an exclusive write lock.
+
+References registered with Descriptor locators are indexed by descriptor fields,
+so lookups by descriptors check only the references that can possibly match
+instead of scanning the entire list.
+
+Components can also be registered with factories using PutFactory. Such components
+are created lazily when they are located for the first time.
+
+Listeners added with AddListener are notified when components are put or removed.
+Notifications are sent after the lock is released, so listeners are free to use these references.
+
 see
 IReferences
 
@@ -29,6 +47,9 @@ Example:
 */
 type References struct {
 	references []*Reference
+	index      *referenceIndex
+	listeners  []IReferenceListener
+	lock       sync.RWMutex
 }
 
 // Creates a new instance of references and initializes it with references.
@@ -36,6 +57,7 @@ type References struct {
 func NewEmptyReferences() *References {
 	return &References{
 		references: make([]*Reference, 0, 10),
+		index:      newReferenceIndex(),
 	}
 }
 
@@ -69,8 +91,94 @@ func (c *References) Put(locator interface{}, component interface{}) {
 		panic("Component cannot be null")
 	}
 
-	reference := NewReference(locator, component)
+	c.putReference(NewReference(locator, component))
+}
+
+// Puts a new lazy reference into this reference map.
+// The component is created by the factory when it is located for the first time,
+// or every time it is located in Transient scope.
+// Factory errors are returned by lookups as InvocationError with the locator in the details.
+// see
+// IReferenceFactory
+// Parameters:
+//  - locator interface{}
+//  a locator to find the reference by.
+//  - factory IReferenceFactory
+//  a factory to create the component.
+//  - scope ReferenceScope
+//  Singleton or Transient scope of the created component.
+func (c *References) PutFactory(locator interface{}, factory IReferenceFactory, scope ReferenceScope) {
+	c.putReference(NewLazyReference(locator, factory, scope))
+}
+
+func (c *References) putReference(reference *Reference) {
+	c.lock.Lock()
+
+	if c.index == nil {
+		c.index = newReferenceIndex()
+	}
+
 	c.references = append(c.references, reference)
+	c.index.add(reference)
+	listeners := c.listeners
+
+	c.lock.Unlock()
+
+	notifyReferenceListeners(listeners, ReferenceAdded, []*Reference{reference})
+}
+
+// Adds a listener to receive notifications when components are put into or removed from these references.
+// see
+// IReferenceListener
+// Parameters:
+//  - listener IReferenceListener
+//  the listener reference to add.
+func (c *References) AddListener(listener IReferenceListener) {
+	if listener == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Listeners are copied on write, so notifications can run without the lock
+	listeners := make([]IReferenceListener, 0, len(c.listeners)+1)
+	listeners = append(listeners, c.listeners...)
+	c.listeners = append(listeners, listener)
+}
+
+// Removes a listener, so that it no longer receives notifications for these references.
+// Parameters:
+//  - listener IReferenceListener
+//  the listener reference to remove.
+func (c *References) RemoveListener(listener IReferenceListener) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for index, item := range c.listeners {
+		if isSameListener(item, listener) {
+			listeners := make([]IReferenceListener, 0, len(c.listeners)-1)
+			listeners = append(listeners, c.listeners[:index]...)
+			c.listeners = append(listeners, c.listeners[index+1:]...)
+			break
+		}
+	}
+}
+
+func isSameListener(listener1 IReferenceListener, listener2 IReferenceListener) bool {
+	// Functions and other not comparable listeners can't be found
+	if listener1 == nil || listener2 == nil || !reflect.TypeOf(listener1).Comparable() {
+		return false
+	}
+	return listener1 == listener2
+}
+
+func notifyReferenceListeners(listeners []IReferenceListener, change ReferenceChange, references []*Reference) {
+	for _, reference := range references {
+		for _, listener := range listeners {
+			listener.OnReferenceChanged(change, reference.Locator(), reference.Component())
+		}
+	}
 }
 
 // Removes a previously added reference that matches specified locator. If many references match the locator, it removes only the first one. When all references shall be removed, use removeAll method instead.
@@ -80,20 +188,30 @@ func (c *References) Put(locator interface{}, component interface{}) {
 //  - locator interface{}
 //  a locator to remove reference
 // Returns interface{}
-// the removed component reference.
+// the removed component reference or nil if a lazy component was not created yet.
 func (c *References) Remove(locator interface{}) interface{} {
 	if locator == nil {
 		return nil
 	}
 
+	c.lock.Lock()
+
 	for index := len(c.references) - 1; index >= 0; index-- {
 		reference := c.references[index]
 		if reference.Match(locator) {
 			c.references = append(c.references[:index], c.references[index+1:]...)
+			if c.index != nil {
+				c.index.remove(reference)
+			}
+			listeners := c.listeners
+			c.lock.Unlock()
+
+			notifyReferenceListeners(listeners, ReferenceRemoved, []*Reference{reference})
 			return reference.Component()
 		}
 	}
 
+	c.lock.Unlock()
 	return nil
 }
 
@@ -110,21 +228,69 @@ func (c *References) RemoveAll(locator interface{}) []interface{} {
 		return components
 	}
 
+	c.lock.Lock()
+
+	removed := make([]*Reference, 0, 5)
 	for index := len(c.references) - 1; index >= 0; index-- {
 		reference := c.references[index]
 		if reference.Match(locator) {
 			c.references = append(c.references[:index], c.references[index+1:]...)
-			components = append(components, reference.Component())
+			if c.index != nil {
+				c.index.remove(reference)
+			}
+			removed = append(removed, reference)
+			if component := reference.Component(); component != nil {
+				components = append(components, component)
+			}
 		}
 	}
+	listeners := c.listeners
+
+	c.lock.Unlock()
 
+	notifyReferenceListeners(listeners, ReferenceRemoved, removed)
 	return components
 }
 
+// Removes all references and returns their components in the order they were registered.
+func (c *References) removeAllReferences() []interface{} {
+	c.lock.Lock()
+
+	removed := c.references
+	components := make([]interface{}, 0, len(removed))
+	for _, reference := range removed {
+		if component := reference.Component(); component != nil {
+			components = append(components, component)
+		}
+	}
+
+	c.references = make([]*Reference, 0, 10)
+	c.index = newReferenceIndex()
+	listeners := c.listeners
+
+	c.lock.Unlock()
+
+	notifyReferenceListeners(listeners, ReferenceRemoved, removed)
+	return components
+}
+
+// Gets all registered references without creating lazy components.
+func (c *References) getReferences() []*Reference {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	references := make([]*Reference, len(c.references))
+	copy(references, c.references)
+	return references
+}
+
 // Gets locators for all registered component references in this reference map.
 // Returns []interface{}
 // a list with component locators.
 func (c *References) GetAllLocators() []interface{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	components := make([]interface{}, len(c.references), len(c.references))
 
 	for index, reference := range c.references {
@@ -135,13 +301,19 @@ func (c *References) GetAllLocators() []interface{} {
 }
 
 // Gets all component references registered in this reference map.
+// Lazy components are included only after they were created in Singleton scope.
 // Returns []interface{}
 // a list with component references.
 func (c *References) GetAll() []interface{} {
-	components := make([]interface{}, len(c.references), len(c.references))
+	c.lock.RLock()
+	defer c.lock.RUnlock()
 
-	for index, reference := range c.references {
-		components[index] = reference.Component()
+	components := make([]interface{}, 0, len(c.references))
+
+	for _, reference := range c.references {
+		if component := reference.Component(); component != nil {
+			components = append(components, component)
+		}
 	}
 
 	return components
@@ -154,11 +326,8 @@ func (c *References) GetAll() []interface{} {
 // Returns interface{}
 // a matching component reference or nil if nothing was found.
 func (c *References) GetOneOptional(locator interface{}) interface{} {
-	components, err := c.Find(locator, false)
-	if err != nil || len(components) == 0 {
-		return nil
-	}
-	return components[0]
+	component, _ := c.findOne(locator, false)
+	return component
 }
 
 // Gets a required component reference that matches specified locator.
@@ -170,11 +339,7 @@ func (c *References) GetOneOptional(locator interface{}) interface{} {
 // Returns interface{}
 // a matching component reference.
 func (c *References) GetOneRequired(locator interface{}) (interface{}, error) {
-	components, err := c.Find(locator, true)
-	if err != nil || len(components) == 0 {
-		return nil, err
-	}
-	return components[0], nil
+	return c.findOne(locator, true)
 }
 
 // Gets all component references that match specified locator.
@@ -215,15 +380,17 @@ func (c *References) Find(locator interface{}, required bool) ([]interface{}, er
 		panic("Locator cannot be null")
 	}
 
-	components := make([]interface{}, 0, 2)
+	references := c.findReferences(locator)
+	components := make([]interface{}, 0, len(references))
 
-	// Search all references
-	for index := len(c.references) - 1; index >= 0; index-- {
-		reference := c.references[index]
-		if reference.Match(locator) {
-			component := reference.Component()
-			components = append(components, component)
+	// Lazy components are created outside of the lock,
+	// so factories are free to use these references
+	for _, reference := range references {
+		component, err := reference.Resolve()
+		if err != nil {
+			return components, err
 		}
+		components = append(components, component)
 	}
 
 	if len(components) == 0 && required {
@@ -234,6 +401,66 @@ func (c *References) Find(locator interface{}, required bool) ([]interface{}, er
 	return components, nil
 }
 
+// Finds the first component that matches the locator.
+// Lazy references are resolved one by one until one of them creates its component,
+// so other matching factories are not called. When none of the references resolves, the first error is returned.
+func (c *References) findOne(locator interface{}, required bool) (interface{}, error) {
+	if locator == nil {
+		panic("Locator cannot be null")
+	}
+
+	var firstErr error
+	for _, reference := range c.findReferences(locator) {
+		component, err := reference.Resolve()
+		if err == nil {
+			return component, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if required {
+		return nil, NewReferenceError("", locator)
+	}
+	return nil, nil
+}
+
+func (c *References) findReferences(locator interface{}) []*Reference {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	// Search indexed references by descriptor
+	var fields [5]string
+	indexable := false
+	if descriptor, ok := locator.(*Descriptor); ok && descriptor != nil {
+		fields, indexable = descriptorFields(descriptor), true
+	} else if pattern, ok := locator.(*PatternDescriptor); ok && pattern != nil {
+		fields, indexable = pattern.exactFields(), true
+	}
+
+	if indexable && c.index != nil {
+		references, indexed := c.index.find(fields, locator)
+		if indexed {
+			return references
+		}
+	}
+
+	// Search all references
+	references := make([]*Reference, 0, 2)
+	for index := len(c.references) - 1; index >= 0; index-- {
+		reference := c.references[index]
+		if reference.Match(locator) {
+			references = append(references, reference)
+		}
+	}
+
+	return references
+}
+
 // Creates a new References from a list of key-value pairs called tuples.
 // Parameters:
 //  - tuples  ...interface{}
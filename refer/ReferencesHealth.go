@@ -0,0 +1,47 @@
+package refer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+)
+
+// Checks health of all components stored in references concurrently.
+// Components in the report are named by their locators.
+// Lazy components that were not created yet are not checked.
+// see
+// run.HealthChecker
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - references IReferences
+//  the references with components to check.
+//  - timeout time.Duration
+//  a maximum time to check one component (0 for no timeout).
+// Returns *run.HealthReport
+// the health report.
+func CheckReferencesHealth(ctx context.Context, references IReferences, timeout time.Duration) *run.HealthReport {
+	components := map[string]interface{}{}
+
+	for _, reference := range getGraphReferences(references) {
+		component := reference.Component()
+		if component == nil {
+			continue
+		}
+
+		base := fmt.Sprint(reference.Locator())
+		if reference.Locator() == nil {
+			base = fmt.Sprintf("%T", component)
+		}
+		name := base
+		for index := 2; components[name] != nil; index++ {
+			name = base + "#" + strconv.Itoa(index)
+		}
+		components[name] = component
+	}
+
+	return run.HealthChecker.CheckNamed(ctx, components, timeout)
+}
@@ -0,0 +1,170 @@
+package refer
+
+/*
+References with its own registrations that fall back to a parent for components they can't resolve.
+
+It is used to create per-tenant or per-request sub-containers. Components registered in the scope
+shadow parent components that match the same locator. Put, Remove and RemoveAll change only
+the scope registrations, and GetAll and GetAllLocators return only components registered in the scope.
+
+When the scope is no longer needed it shall be closed. Closing removes all components from the scope,
+closes them in the reverse order and unsets their references. Parent components are not affected.
+
+see
+References
+
+see
+IReferences
+
+Example:
+ tenantReferences := NewScopedReferences(references)
+ tenantReferences.Put(NewDescriptor("mygroup", "persistence", "memory", "tenant1", "1.0"), persistence)
+
+ // Gets persistence from the scope and logger from the parent references
+ persistence, _ := tenantReferences.GetOneRequired(NewDescriptor("mygroup", "persistence", "*", "*", "1.0"))
+ logger, _ := tenantReferences.GetOneRequired(NewDescriptor("pip-services", "logger", "*", "*", "1.0"))
+ ...
+ tenantReferences.Close("123")
+*/
+type ScopedReferences struct {
+	*References
+	parent IReferences
+}
+
+// Creates a new scope of references.
+// Parameters:
+//  - parent IReferences
+//  the parent references to find components not registered in the scope.
+// Returns *ScopedReferences
+func NewScopedReferences(parent IReferences) *ScopedReferences {
+	if parent == nil {
+		panic("Parent references cannot be nil")
+	}
+
+	return &ScopedReferences{
+		References: NewEmptyReferences(),
+		parent:     parent,
+	}
+}
+
+// Creates a new scope of references and initializes it with references.
+// Parameters:
+//  - parent IReferences
+//  the parent references to find components not registered in the scope.
+//  - tuples ...interface{}
+//  a list of values where odd elements are locators and the following even elements are component references
+// Returns *ScopedReferences
+func NewScopedReferencesFromTuples(parent IReferences, tuples ...interface{}) *ScopedReferences {
+	c := NewScopedReferences(parent)
+
+	for index := 0; index < len(tuples); index += 2 {
+		if index+1 >= len(tuples) {
+			break
+		}
+
+		c.Put(tuples[index], tuples[index+1])
+	}
+
+	return c
+}
+
+// Gets the parent references.
+// Returns IReferences
+// the parent references.
+func (c *ScopedReferences) Parent() IReferences {
+	return c.parent
+}
+
+// Creates a nested scope that falls back to this scope.
+// Returns *ScopedReferences
+// a newly created scope.
+func (c *ScopedReferences) CreateScope() *ScopedReferences {
+	return NewScopedReferences(c)
+}
+
+// Gets an optional component reference that matches specified locator.
+// Parameters:
+//  - locator interface{}
+//  the locator to find references by.
+// Returns interface{}
+// a matching component reference or nil if nothing was found.
+func (c *ScopedReferences) GetOneOptional(locator interface{}) interface{} {
+	component, err := c.References.findOne(locator, false)
+	if err != nil || component != nil {
+		return component
+	}
+	return c.parent.GetOneOptional(locator)
+}
+
+// Gets a required component reference that matches specified locator.
+// throws
+// a ReferenceError when no references found.
+// Parameters:
+//  - locator interface{}
+//  the locator to find a reference by.
+// Returns interface{}
+// a matching component reference.
+func (c *ScopedReferences) GetOneRequired(locator interface{}) (interface{}, error) {
+	component, err := c.References.findOne(locator, false)
+	if err != nil || component != nil {
+		return component, err
+	}
+	return c.parent.GetOneRequired(locator)
+}
+
+// Gets all component references that match specified locator.
+// Parameters:
+//  - locator interface{}
+//  the locator to find references by.
+// Returns []interface{}
+// a list with matching component references or empty list if nothing was found.
+func (c *ScopedReferences) GetOptional(locator interface{}) []interface{} {
+	components, _ := c.Find(locator, false)
+	return components
+}
+
+// Gets all component references that match specified locator. At least one component reference must be present. If it doesn't the method throws an error.
+// throws
+// a ReferenceError when no references found.
+// Parameters:
+//  - locator interface{}
+//  the locator to find references by.
+// Returns []interface{}
+// a list with matching component references.
+func (c *ScopedReferences) GetRequired(locator interface{}) ([]interface{}, error) {
+	return c.Find(locator, true)
+}
+
+// Gets all component references that match specified locator.
+// Components registered in the scope are returned when found, otherwise the call is passed to the parent references.
+// throws
+// a ReferenceError when required is set to true but no references found.
+// Parameters:
+//  - locator interface{}
+//  the locator to find a reference by.
+//  - required bool
+//  forces to raise an exception if no reference is found.
+// Returns []interface{}
+// a list with matching component references.
+func (c *ScopedReferences) Find(locator interface{}, required bool) ([]interface{}, error) {
+	components, err := c.References.Find(locator, false)
+	if err != nil || len(components) > 0 {
+		return components, err
+	}
+
+	return c.parent.Find(locator, required)
+}
+
+// Closes the scope. It removes all components registered in the scope,
+// closes them in the reverse order and unsets their references.
+// All components are closed even if some of them fail. The first occured error is returned.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns error
+func (c *ScopedReferences) Close(correlationId string) error {
+	components := c.References.removeAllReferences()
+	err := closeComponents(correlationId, components)
+	unsetReferences(components)
+	return err
+}
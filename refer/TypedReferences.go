@@ -0,0 +1,187 @@
+package refer
+
+import "reflect"
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func castComponent[T any](locator interface{}, component interface{}) (T, error) {
+	result, ok := component.(T)
+	if !ok {
+		var empty T
+		return empty, NewReferenceTypeError("", locator, typeName[T](), component)
+	}
+	return result, nil
+}
+
+func castComponents[T any](locator interface{}, components []interface{}) ([]T, error) {
+	results := make([]T, 0, len(components))
+	for _, component := range components {
+		result, err := castComponent[T](locator, component)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+/*
+Gets an optional component reference that matches specified locator and casts it to type T.
+
+This and other generic helpers locate components in IReferences and DependencyResolver and cast them to the expected type.
+Components that do not implement the expected type are reported as ReferenceError instead of panics
+caused by unchecked type assertions.
+
+throws
+a ReferenceError when the component does not have type T.
+
+Parameters:
+ - references IReferences
+ the references to find the component in.
+ - locator interface{}
+ the locator to find references by.
+Returns T, error
+a matching component reference or empty value if nothing was found, and error.
+
+Example:
+ persistence, err := GetOneRequired[IMyPersistence](references,
+ 	NewDescriptor("mygroup", "persistence", "*", "*", "1.0"))
+ if err != nil {
+ 	return err
+ }
+
+ logger, err := GetOneOptionalDependency[ILogger](dependencyResolver, "logger")
+*/
+func GetOneOptional[T any](references IReferences, locator interface{}) (T, error) {
+	component := references.GetOneOptional(locator)
+	if component == nil {
+		var empty T
+		return empty, nil
+	}
+	return castComponent[T](locator, component)
+}
+
+// Gets a required component reference that matches specified locator and casts it to type T.
+// throws
+// a ReferenceError when no references found or the component does not have type T.
+// Parameters:
+//  - references IReferences
+//  the references to find the component in.
+//  - locator interface{}
+//  the locator to find a reference by.
+// Returns T, error
+// a matching component reference and error.
+func GetOneRequired[T any](references IReferences, locator interface{}) (T, error) {
+	component, err := references.GetOneRequired(locator)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	return castComponent[T](locator, component)
+}
+
+// Gets all component references that match specified locator and casts them to type T.
+// throws
+// a ReferenceError when one of the components does not have type T.
+// Parameters:
+//  - references IReferences
+//  the references to find the components in.
+//  - locator interface{}
+//  the locator to find references by.
+// Returns []T, error
+// a list with matching component references or empty list if nothing was found, and error.
+func GetOptional[T any](references IReferences, locator interface{}) ([]T, error) {
+	return castComponents[T](locator, references.GetOptional(locator))
+}
+
+// Gets all component references that match specified locator and casts them to type T.
+// At least one component reference must be present.
+// throws
+// a ReferenceError when no references found or one of the components does not have type T.
+// Parameters:
+//  - references IReferences
+//  the references to find the components in.
+//  - locator interface{}
+//  the locator to find references by.
+// Returns []T, error
+// a list with matching component references and error.
+func GetRequired[T any](references IReferences, locator interface{}) ([]T, error) {
+	components, err := references.GetRequired(locator)
+	if err != nil {
+		return []T{}, err
+	}
+	return castComponents[T](locator, components)
+}
+
+// Gets one optional dependency by its name and casts it to type T.
+// throws
+// a ReferenceError when the dependency does not have type T.
+// Parameters:
+//  - resolver *DependencyResolver
+//  the resolver to locate the dependency.
+//  - name string
+//  the dependency name to locate.
+// Returns T, error
+// a dependency reference or empty value if the dependency was not found, and error.
+func GetOneOptionalDependency[T any](resolver *DependencyResolver, name string) (T, error) {
+	component := resolver.GetOneOptional(name)
+	if component == nil {
+		var empty T
+		return empty, nil
+	}
+	return castComponent[T](resolver.Locate(name), component)
+}
+
+// Gets one required dependency by its name and casts it to type T.
+// throws
+// a ReferenceError when the dependency was not found or does not have type T.
+// Parameters:
+//  - resolver *DependencyResolver
+//  the resolver to locate the dependency.
+//  - name string
+//  the dependency name to locate.
+// Returns T, error
+// a dependency reference and error.
+func GetOneRequiredDependency[T any](resolver *DependencyResolver, name string) (T, error) {
+	component, err := resolver.GetOneRequired(name)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+	return castComponent[T](resolver.Locate(name), component)
+}
+
+// Gets all optional dependencies by their name and casts them to type T.
+// throws
+// a ReferenceError when one of the dependencies does not have type T.
+// Parameters:
+//  - resolver *DependencyResolver
+//  the resolver to locate the dependencies.
+//  - name string
+//  the dependency name to locate.
+// Returns []T, error
+// a list with found dependencies or empty list of no dependencies was found, and error.
+func GetOptionalDependencies[T any](resolver *DependencyResolver, name string) ([]T, error) {
+	return castComponents[T](resolver.Locate(name), resolver.GetOptional(name))
+}
+
+// Gets all required dependencies by their name and casts them to type T.
+// At least one dependency must be present.
+// throws
+// a ReferenceError when no dependencies were found or one of them does not have type T.
+// Parameters:
+//  - resolver *DependencyResolver
+//  the resolver to locate the dependencies.
+//  - name string
+//  the dependency name to locate.
+// Returns []T, error
+// a list with found dependencies and error.
+func GetRequiredDependencies[T any](resolver *DependencyResolver, name string) ([]T, error) {
+	components, err := resolver.GetRequired(name)
+	if err != nil {
+		return []T{}, err
+	}
+	return castComponents[T](resolver.Locate(name), components)
+}
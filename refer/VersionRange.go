@@ -0,0 +1,328 @@
+package refer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Range of semantic versions used to match the version field in Descriptor.
+
+The range supports the following syntax:
+
+ ^1.2       - compatible versions: >=1.2.0 <2.0.0 (^0.2 means >=0.2.0 <0.3.0)
+ ~1.2       - patch versions: >=1.2.0 <1.3.0
+ 1.x        - any version with the major version 1 (also 1.* or 1.2.x)
+ >=1.1 <2.0 - comparisons with >, >=, <, <= or = separated by spaces must all be satisfied
+ 1.x || 3.x - either of the ranges separated by ||
+
+Missing minor and patch numbers in versions are treated as 0, so "1.2" is matched as "1.2.0".
+
+see
+Descriptor
+
+Example:
+ versionRange, _ := ParseVersionRange(">=1.1 <2.0")
+
+ versionRange.Contains("1.2");    // Result: true
+ versionRange.Contains("2.0");    // Result: false
+*/
+type VersionRange struct {
+	value string
+	sets  [][]*versionComparator
+}
+
+type semanticVersion struct {
+	numbers    [3]int
+	prerelease string
+}
+
+type versionComparator struct {
+	operator string
+	version  semanticVersion
+}
+
+// Parses a version range from its string representation.
+// throws
+// a ConfigError if the range is of a wrong format.
+// Parameters:
+//  - value string
+//  the version range like "^1.0", ">=1.1 <2.0" or "1.x".
+// Returns *VersionRange, error
+// a newly created VersionRange and error.
+func ParseVersionRange(value string) (*VersionRange, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errors.NewConfigError("", "BAD_VERSION_RANGE", "Version range cannot be empty")
+	}
+
+	result := &VersionRange{value: value}
+
+	for _, part := range strings.Split(value, "||") {
+		comparators, ok := parseVersionComparators(part)
+		if !ok {
+			return nil, errors.NewConfigError("", "BAD_VERSION_RANGE", "Version range "+value+" is in wrong format").
+				WithDetails("range", value)
+		}
+		result.sets = append(result.sets, comparators)
+	}
+
+	return result, nil
+}
+
+// Checks if the value uses version range syntax rather than a plain version.
+func isVersionRange(value string) bool {
+	if strings.ContainsAny(value, "^~<>=| ") {
+		return true
+	}
+	for _, token := range strings.Split(value, ".") {
+		if isVersionWildcard(token) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVersionWildcard(value string) bool {
+	return value == "x" || value == "X" || value == "*"
+}
+
+// Parses a version with optional wildcards like "1.2.3", "1.x" or "1".
+// Returns the specified numbers, the prerelease tag and false if the version is invalid.
+func parsePartialVersion(value string) ([]int, string, bool) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "v"), "V")
+
+	prerelease := ""
+	if index := strings.IndexAny(value, "-+"); index >= 0 {
+		if value[index] == '-' {
+			prerelease = value[index+1:]
+			if plus := strings.Index(prerelease, "+"); plus >= 0 {
+				prerelease = prerelease[:plus]
+			}
+		}
+		value = value[:index]
+	}
+
+	tokens := strings.Split(value, ".")
+	if len(tokens) > 3 {
+		return nil, "", false
+	}
+
+	numbers := make([]int, 0, 3)
+	wildcard := false
+	for _, token := range tokens {
+		if isVersionWildcard(token) {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return nil, "", false
+		}
+
+		number, err := strconv.Atoi(token)
+		if err != nil || number < 0 {
+			return nil, "", false
+		}
+		numbers = append(numbers, number)
+	}
+
+	if wildcard && prerelease != "" {
+		return nil, "", false
+	}
+
+	return numbers, prerelease, true
+}
+
+func newSemanticVersion(numbers []int, prerelease string) semanticVersion {
+	version := semanticVersion{prerelease: prerelease}
+	copy(version.numbers[:], numbers)
+	return version
+}
+
+// Gets the first version above all versions starting with the specified numbers.
+func nextSemanticVersion(numbers []int) semanticVersion {
+	next := make([]int, len(numbers))
+	copy(next, numbers)
+	next[len(next)-1]++
+	return newSemanticVersion(next, "")
+}
+
+func compareSemanticVersions(version1 semanticVersion, version2 semanticVersion) int {
+	for index := range version1.numbers {
+		if version1.numbers[index] != version2.numbers[index] {
+			if version1.numbers[index] < version2.numbers[index] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// Prerelease versions go before the release
+	if version1.prerelease == version2.prerelease {
+		return 0
+	}
+	if version1.prerelease == "" {
+		return 1
+	}
+	if version2.prerelease == "" {
+		return -1
+	}
+	return strings.Compare(version1.prerelease, version2.prerelease)
+}
+
+func parseVersionComparators(value string) ([]*versionComparator, bool) {
+	tokens := strings.Fields(value)
+	if len(tokens) == 0 {
+		return nil, false
+	}
+
+	comparators := make([]*versionComparator, 0, 2)
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+
+		// Join operators separated from versions by spaces like ">= 1.0"
+		if strings.Trim(token, "^~<>=") == "" && index+1 < len(tokens) {
+			index++
+			token += tokens[index]
+		}
+
+		result, ok := parseVersionComparator(token)
+		if !ok {
+			return nil, false
+		}
+		comparators = append(comparators, result...)
+	}
+
+	return comparators, true
+}
+
+func parseVersionComparator(value string) ([]*versionComparator, bool) {
+	operator := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(value, prefix) {
+			operator = prefix
+			break
+		}
+	}
+
+	numbers, prerelease, ok := parsePartialVersion(value[len(operator):])
+	if !ok || (len(numbers) == 0 && (operator == "^" || operator == "~")) {
+		return nil, false
+	}
+
+	version := newSemanticVersion(numbers, prerelease)
+	complete := len(numbers) == 3
+
+	switch operator {
+	case "^":
+		// Allow changes that do not modify the left-most non-zero number
+		significant := 0
+		for significant < len(numbers)-1 && numbers[significant] == 0 {
+			significant++
+		}
+		return []*versionComparator{
+			{operator: ">=", version: version},
+			{operator: "<", version: nextSemanticVersion(numbers[:significant+1])},
+		}, true
+	case "~":
+		significant := 2
+		if len(numbers) < 2 {
+			significant = 1
+		}
+		return []*versionComparator{
+			{operator: ">=", version: version},
+			{operator: "<", version: nextSemanticVersion(numbers[:significant])},
+		}, true
+	case ">=", "<":
+		if len(numbers) == 0 {
+			if operator == "<" {
+				return []*versionComparator{{operator: "<", version: version}}, true
+			}
+			return []*versionComparator{}, true
+		}
+		return []*versionComparator{{operator: operator, version: version}}, true
+	case ">":
+		if complete {
+			return []*versionComparator{{operator: ">", version: version}}, true
+		}
+		if len(numbers) == 0 {
+			return []*versionComparator{{operator: "<", version: semanticVersion{}}}, true
+		}
+		return []*versionComparator{{operator: ">=", version: nextSemanticVersion(numbers)}}, true
+	case "<=":
+		if complete {
+			return []*versionComparator{{operator: "<=", version: version}}, true
+		}
+		if len(numbers) == 0 {
+			return []*versionComparator{}, true
+		}
+		return []*versionComparator{{operator: "<", version: nextSemanticVersion(numbers)}}, true
+	default:
+		// Plain and "=" versions are matched as x-ranges
+		if complete {
+			return []*versionComparator{{operator: "=", version: version}}, true
+		}
+		if len(numbers) == 0 {
+			return []*versionComparator{}, true
+		}
+		return []*versionComparator{
+			{operator: ">=", version: version},
+			{operator: "<", version: nextSemanticVersion(numbers)},
+		}, true
+	}
+}
+
+func (c *versionComparator) test(version semanticVersion) bool {
+	result := compareSemanticVersions(version, c.version)
+	switch c.operator {
+	case ">=":
+		return result >= 0
+	case ">":
+		return result > 0
+	case "<=":
+		return result <= 0
+	case "<":
+		return result < 0
+	default:
+		return result == 0
+	}
+}
+
+// Checks if the version belongs to this range.
+// Parameters:
+//  - version string
+//  the version to check like "1.2" or "1.2.3".
+// Returns bool
+// true if the version is within the range and false otherwise or if the version is not a valid semantic version.
+func (c *VersionRange) Contains(version string) bool {
+	version = strings.TrimSpace(version)
+	numbers, prerelease, ok := parsePartialVersion(version)
+	if !ok || len(numbers) == 0 || isVersionRange(version) {
+		return false
+	}
+	value := newSemanticVersion(numbers, prerelease)
+
+	for _, comparators := range c.sets {
+		matched := true
+		for _, comparator := range comparators {
+			if !comparator.test(value) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Gets a string representation of the range in the same format it was parsed from.
+// Returns string
+// a string representation of the range.
+func (c *VersionRange) String() string {
+	return c.value
+}
@@ -0,0 +1,240 @@
+package run
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Bulkhead that limits the number of concurrent calls to protect components from overload.
+
+Calls above the concurrency limit wait in a queue. When the queue is full or the call waits
+longer than the queue timeout, it is rejected with InvalidStateError with code "BULKHEAD_FULL"
+and "retry_after" detail in milliseconds.
+
+The bulkhead can be reconfigured while calls are running. When the concurrency limit is decreased,
+running calls are completed and new calls wait until the number of running calls drops below the new limit.
+
+Configuration parameters
+ bulkhead:
+   max_concurrent: maximum number of concurrent calls
+   max_queue: maximum number of waiting calls
+   queue_timeout: maximum time to wait in the queue in milliseconds (0 to wait without timeout)
+
+see
+RateLimiter
+
+Example:
+ bulkhead := NewBulkhead(10, 100, time.Second)
+ result, err := bulkhead.ExecuteOne("123", component, args)
+*/
+type Bulkhead struct {
+	maxConcurrent int
+	maxQueue      int
+	queueTimeout  time.Duration
+	running       int
+	queued        int
+	released      chan struct{}
+	lock          sync.Mutex
+}
+
+// Creates a new instance of the bulkhead.
+// Parameters:
+//  - maxConcurrent int
+//  maximum number of concurrent calls.
+//  - maxQueue int
+//  maximum number of waiting calls.
+//  - queueTimeout time.Duration
+//  maximum time to wait in the queue.
+// Returns *Bulkhead
+func NewBulkhead(maxConcurrent int, maxQueue int, queueTimeout time.Duration) *Bulkhead {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	return &Bulkhead{
+		maxConcurrent: maxConcurrent,
+		maxQueue:      maxQueue,
+		queueTimeout:  queueTimeout,
+		released:      make(chan struct{}),
+	}
+}
+
+// Configures the bulkhead with specified parameters.
+// The configuration can be changed while calls are running.
+// see
+// ConfigParams
+// Parameters:
+//  - config *config.ConfigParams
+//  configuration parameters to set.
+func (c *Bulkhead) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	maxConcurrent := config.GetAsIntegerWithDefault("bulkhead.max_concurrent", c.maxConcurrent)
+	if maxConcurrent > 0 && maxConcurrent != c.maxConcurrent {
+		c.maxConcurrent = maxConcurrent
+		// Waiting calls can take new slots
+		c.notifyReleased()
+	}
+	c.maxQueue = config.GetAsIntegerWithDefault("bulkhead.max_queue", c.maxQueue)
+	c.queueTimeout = time.Duration(config.GetAsLongWithDefault("bulkhead.queue_timeout", c.queueTimeout.Milliseconds())) * time.Millisecond
+}
+
+// Gets the number of calls running at the moment.
+// Returns int
+// the number of running calls.
+func (c *Bulkhead) Running() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.running
+}
+
+// Gets the number of calls waiting in the queue.
+// Returns int
+// the number of waiting calls.
+func (c *Bulkhead) Queued() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.queued
+}
+
+func (c *Bulkhead) rejectError(correlationId string) error {
+	return errors.NewInvalidStateError(
+		correlationId,
+		"BULKHEAD_FULL",
+		"Too many concurrent calls",
+	).WithDetails("max_concurrent", c.maxConcurrent).
+		WithDetails("retry_after", c.queueTimeout.Milliseconds())
+}
+
+// Wakes up calls waiting in the queue.
+// Shall be called under lock.
+func (c *Bulkhead) notifyReleased() {
+	close(c.released)
+	c.released = make(chan struct{})
+}
+
+// Takes a slot for a call, waiting in the queue if all slots are busy.
+// Every successful Acquire shall be followed by Release.
+// throws
+// InvalidStateError when the queue is full or the queue timeout elapsed.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+// Returns error
+func (c *Bulkhead) Acquire(ctx context.Context) error {
+	c.lock.Lock()
+	if c.running < c.maxConcurrent {
+		c.running++
+		c.lock.Unlock()
+		return nil
+	}
+
+	if c.queued >= c.maxQueue {
+		err := c.rejectError(GetCorrelationId(ctx))
+		c.lock.Unlock()
+		return err
+	}
+	c.queued++
+	timeout := c.queueTimeout
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		c.queued--
+		c.lock.Unlock()
+	}()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	for {
+		c.lock.Lock()
+		if c.running < c.maxConcurrent {
+			c.running++
+			c.lock.Unlock()
+			return nil
+		}
+		released := c.released
+		c.lock.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+			c.lock.Lock()
+			defer c.lock.Unlock()
+			return c.rejectError(GetCorrelationId(ctx))
+		}
+	}
+}
+
+// Releases the slot taken by Acquire.
+func (c *Bulkhead) Release() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.running > 0 {
+		c.running--
+		c.notifyReleased()
+	}
+}
+
+// Calls the action when a slot is available.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - action func() (interface{}, error)
+//  the function to call.
+// Returns interface{}, error
+// the action result or InvalidStateError when the bulkhead is full.
+func (c *Bulkhead) Call(ctx context.Context, action func() (interface{}, error)) (interface{}, error) {
+	if err := c.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.Release()
+
+	return action()
+}
+
+// Executes specific component when a slot is available.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *Bulkhead) ExecuteOne(correlationId string, component interface{}, args *Parameters) (interface{}, error) {
+	return c.ExecuteOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Executes specific component using the context when a slot is available.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *Bulkhead) ExecuteOneWithContext(ctx context.Context, component interface{}, args *Parameters) (interface{}, error) {
+	return c.Call(ctx, func() (interface{}, error) {
+		return Executor.ExecuteOneWithContext(ctx, component, args)
+	})
+}
@@ -0,0 +1,329 @@
+package run
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+// The circuit is closed and calls pass through.
+const CircuitClosed = "closed"
+
+// The circuit is open and calls fail fast.
+const CircuitOpen = "open"
+
+// The circuit lets a limited number of trial calls through to check if the dependency has recovered.
+const CircuitHalfOpen = "half-open"
+
+/*
+Circuit breaker that stops calls to a failing dependency.
+
+The breaker is closed while the dependency works. It records results of calls over a sliding time window,
+and when the share of failed calls reaches the failure rate, the breaker opens. While it is open, calls fail fast
+with ConnectionError without reaching the dependency. After the open timeout the breaker becomes half-open
+and lets trial calls through. When they succeed the breaker closes again, otherwise it opens for another timeout.
+
+Only errors caused by communication problems are counted as failures: ApplicationError with NoResponse
+or FailedInvocation category. Other errors, for instance BadRequest, mean the dependency is working.
+
+State changes are sent to an INotifiable event, for instance commands.Event,
+with "name", "from" and "to" parameters.
+
+Configuration parameters
+ circuit:
+   window: sliding window to calculate the failure rate in milliseconds (default: 60000)
+   min_requests: minimum number of calls in the window to open the breaker (default: 10)
+   failure_rate: share of failed calls from 0 to 1 to open the breaker (default: 0.5)
+   open_timeout: time the breaker stays open in milliseconds (default: 30000)
+   half_open_requests: number of successful trial calls to close the breaker (default: 1, minimum: 1)
+
+see
+IExecutable
+
+see
+RetryingExecutor
+
+Example:
+ breaker := NewCircuitBreaker("payments")
+ breaker.Configure(config.NewConfigParamsFromTuples(
+ 	"circuit.failure_rate", 0.3,
+ 	"circuit.open_timeout", 10000,
+ ))
+ breaker.SetEvent(commands.NewEvent("circuit_changed"))
+
+ result, err := breaker.ExecuteOne("123", paymentsClient, args)
+*/
+type CircuitBreaker struct {
+	name             string
+	window           time.Duration
+	minRequests      int
+	failureRate      float64
+	openTimeout      time.Duration
+	halfOpenRequests int
+	event            INotifiable
+	clock            IClock
+
+	state     string
+	openedAt  time.Time
+	outcomes  []circuitOutcome
+	failures  int
+	trials    int
+	successes int
+	lock      sync.Mutex
+}
+
+type circuitOutcome struct {
+	time   time.Time
+	failed bool
+}
+
+// Creates a new instance of the circuit breaker with default settings.
+// Parameters:
+//  - name string
+//  the name of the breaker to identify it in errors and events.
+// Returns *CircuitBreaker
+func NewCircuitBreaker(name string) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		window:           60 * time.Second,
+		minRequests:      10,
+		failureRate:      0.5,
+		openTimeout:      30 * time.Second,
+		halfOpenRequests: 1,
+		clock:            NewRealClock(),
+		state:            CircuitClosed,
+		outcomes:         []circuitOutcome{},
+	}
+}
+
+// Configures the breaker with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config *config.ConfigParams
+//  configuration parameters to set.
+func (c *CircuitBreaker) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.window = time.Duration(config.GetAsLongWithDefault("circuit.window", c.window.Milliseconds())) * time.Millisecond
+	c.minRequests = config.GetAsIntegerWithDefault("circuit.min_requests", c.minRequests)
+	c.failureRate = config.GetAsDoubleWithDefault("circuit.failure_rate", c.failureRate)
+	c.openTimeout = time.Duration(config.GetAsLongWithDefault("circuit.open_timeout", c.openTimeout.Milliseconds())) * time.Millisecond
+	c.halfOpenRequests = config.GetAsIntegerWithDefault("circuit.half_open_requests", c.halfOpenRequests)
+	// At least one trial call is required to close the breaker
+	if c.halfOpenRequests < 1 {
+		c.halfOpenRequests = 1
+	}
+}
+
+// Gets the name of the breaker.
+// Returns string
+// the breaker name.
+func (c *CircuitBreaker) Name() string {
+	return c.name
+}
+
+// Sets the event that is notified when the breaker changes its state.
+// Parameters:
+//  - value INotifiable
+//  the event to notify, for instance commands.Event.
+func (c *CircuitBreaker) SetEvent(value INotifiable) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.event = value
+}
+
+// Sets the clock used to measure the window and the open timeout.
+// Parameters:
+//  - value IClock
+//  the clock to be used.
+func (c *CircuitBreaker) SetClock(value IClock) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clock = value
+}
+
+// Gets the current state of the breaker.
+// Returns string
+// CircuitClosed, CircuitOpen or CircuitHalfOpen.
+func (c *CircuitBreaker) State() string {
+	c.lock.Lock()
+	state, from, event := c.refreshState()
+	c.lock.Unlock()
+
+	c.notify("", event, from, state)
+	return state
+}
+
+// Moves the open breaker into half-open state when the open timeout has elapsed.
+// It returns the previous state and the event to notify if the state was changed.
+func (c *CircuitBreaker) refreshState() (string, string, INotifiable) {
+	if c.state == CircuitOpen && !c.clock.Now().Before(c.openedAt.Add(c.openTimeout)) {
+		return c.setState(CircuitHalfOpen), CircuitOpen, c.event
+	}
+	return c.state, c.state, nil
+}
+
+func (c *CircuitBreaker) setState(state string) string {
+	c.state = state
+	c.outcomes = []circuitOutcome{}
+	c.failures = 0
+	c.trials = 0
+	c.successes = 0
+	if state == CircuitOpen {
+		c.openedAt = c.clock.Now()
+	}
+	return state
+}
+
+func (c *CircuitBreaker) notify(correlationId string, event INotifiable, from string, to string) {
+	if event == nil || from == to {
+		return
+	}
+	event.Notify(correlationId, NewParametersFromTuples(
+		"name", c.name,
+		"from", from,
+		"to", to,
+	))
+}
+
+// Checks if the call is allowed and reserves a trial call in half-open state.
+// It returns the state the call is started in.
+func (c *CircuitBreaker) acquire(correlationId string) (string, error) {
+	c.lock.Lock()
+	state, from, event := c.refreshState()
+
+	var err error
+	if state == CircuitOpen || (state == CircuitHalfOpen && c.trials >= c.halfOpenRequests) {
+		retryAfter := c.openedAt.Add(c.openTimeout).Sub(c.clock.Now())
+		if retryAfter < 0 {
+			retryAfter = 0
+		}
+		err = errors.NewConnectionError(
+			correlationId,
+			"CIRCUIT_OPEN",
+			"Circuit breaker "+c.name+" is "+state,
+		).WithDetails("circuit", c.name).WithDetails("retry_after", retryAfter.Milliseconds())
+	} else if state == CircuitHalfOpen {
+		c.trials++
+	}
+	c.lock.Unlock()
+
+	c.notify(correlationId, event, from, state)
+	return state, err
+}
+
+// Records the call result and changes the breaker state.
+func (c *CircuitBreaker) release(correlationId string, state string, failed bool) {
+	c.lock.Lock()
+	from := c.state
+	event := c.event
+
+	// The result of the call started in another state doesn't count
+	if state != c.state {
+		c.lock.Unlock()
+		return
+	}
+
+	switch c.state {
+	case CircuitHalfOpen:
+		if failed {
+			c.setState(CircuitOpen)
+		} else {
+			c.successes++
+			if c.successes >= c.halfOpenRequests {
+				c.setState(CircuitClosed)
+			}
+		}
+	case CircuitClosed:
+		now := c.clock.Now()
+		c.outcomes = append(c.outcomes, circuitOutcome{time: now, failed: failed})
+		if failed {
+			c.failures++
+		}
+
+		// Outcomes are ordered by time, so expired ones are dropped from the start
+		expired := 0
+		for expired < len(c.outcomes) && now.Sub(c.outcomes[expired].time) >= c.window {
+			if c.outcomes[expired].failed {
+				c.failures--
+			}
+			expired++
+		}
+		c.outcomes = c.outcomes[expired:]
+
+		if len(c.outcomes) >= c.minRequests && float64(c.failures) >= c.failureRate*float64(len(c.outcomes)) && c.failures > 0 {
+			c.setState(CircuitOpen)
+		}
+	}
+
+	to := c.state
+	c.lock.Unlock()
+
+	c.notify(correlationId, event, from, to)
+}
+
+// Calls the action through the breaker.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - action func() (interface{}, error)
+//  the function to call.
+// Returns interface{}, error
+// the action result or ConnectionError when the breaker is open.
+// A panic in the action is recorded as a failure and passed to the caller.
+func (c *CircuitBreaker) Call(correlationId string, action func() (interface{}, error)) (interface{}, error) {
+	state, err := c.acquire(correlationId)
+	if err != nil {
+		return nil, err
+	}
+
+	// A panic counts as a failure, so the reserved trial call is always released
+	completed := false
+	defer func() {
+		if !completed {
+			c.release(correlationId, state, true)
+		}
+	}()
+
+	result, err := action()
+	completed = true
+	c.release(correlationId, state, err != nil && IsRetriableError(err))
+	return result, err
+}
+
+// Executes specific component through the breaker.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *CircuitBreaker) ExecuteOne(correlationId string, component interface{}, args *Parameters) (interface{}, error) {
+	return c.ExecuteOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Executes specific component using the context through the breaker.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *CircuitBreaker) ExecuteOneWithContext(ctx context.Context, component interface{}, args *Parameters) (interface{}, error) {
+	return c.Call(GetCorrelationId(ctx), func() (interface{}, error) {
+		return Executor.ExecuteOneWithContext(ctx, component, args)
+	})
+}
@@ -1,5 +1,7 @@
 package run
 
+import "context"
+
 /*
 Helper class that cleans stored object state.
 */
@@ -16,9 +18,31 @@ var Cleaner *TCleaner = &TCleaner{}
 //  the component that is to be cleaned.
 // Returns error
 func (c *TCleaner) ClearOne(correlationId string, component interface{}) error {
-	v, ok := component.(ICleanable)
-	if ok {
-		return v.Clear(correlationId)
+	return c.ClearOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component)
+}
+
+// Clears state of specific component using the context.
+// Components that implement IContextCleanable interface receive the context,
+// components that implement ICleanable interface receive the correlation id carried by the context.
+// see
+// IContextCleanable
+// see
+// ICleanable
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be cleaned.
+// Returns error
+func (c *TCleaner) ClearOneWithContext(ctx context.Context, component interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if v, ok := component.(IContextCleanable); ok {
+		return v.Clear(ctx)
+	}
+	if v, ok := component.(ICleanable); ok {
+		return v.Clear(GetCorrelationId(ctx))
 	}
 	return nil
 }
@@ -32,8 +56,22 @@ func (c *TCleaner) ClearOne(correlationId string, component interface{}) error {
 // the list of components that are to be cleaned.
 // Returns error
 func (c *TCleaner) Clear(correlationId string, components []interface{}) error {
+	return c.ClearWithContext(ContextWithCorrelationId(context.Background(), correlationId), components)
+}
+
+// Clears state of multiple components using the context.
+// Cleaning stops when the context is cancelled.
+// see
+// ClearOneWithContext
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  the list of components that are to be cleaned.
+// Returns error
+func (c *TCleaner) ClearWithContext(ctx context.Context, components []interface{}) error {
 	for _, component := range components {
-		err := c.ClearOne(correlationId, component)
+		err := c.ClearOneWithContext(ctx, component)
 		if err != nil {
 			return err
 		}
@@ -1,5 +1,7 @@
 package run
 
+import "context"
+
 /*
 Helper class that closes previously opened components.
 */
@@ -17,9 +19,31 @@ var Closer *TCloser = &TCloser{}
 // 	the component that is to be closed.
 // Returns error
 func (c *TCloser) CloseOne(correlationId string, component interface{}) error {
-	v, ok := component.(IClosable)
-	if ok {
-		return v.Close(correlationId)
+	return c.CloseOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component)
+}
+
+// Closes specific component using the context.
+// Components that implement IContextClosable interface receive the context,
+// components that implement IClosable interface receive the correlation id carried by the context.
+// see
+// IContextClosable
+// see
+// IClosable
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be closed.
+// Returns error
+func (c *TCloser) CloseOneWithContext(ctx context.Context, component interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if v, ok := component.(IContextClosable); ok {
+		return v.Close(ctx)
+	}
+	if v, ok := component.(IClosable); ok {
+		return v.Close(GetCorrelationId(ctx))
 	}
 	return nil
 }
@@ -33,11 +57,42 @@ func (c *TCloser) CloseOne(correlationId string, component interface{}) error {
 // 			the list of components that are to be closed.
 // Returns error
 func (c *TCloser) Close(correlationId string, components []interface{}) error {
+	return c.CloseWithContext(ContextWithCorrelationId(context.Background(), correlationId), components)
+}
+
+// Closes multiple components using the context.
+// Closing stops when the context is cancelled.
+// see
+// CloseOneWithContext
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  the list of components that are to be closed.
+// Returns error
+func (c *TCloser) CloseWithContext(ctx context.Context, components []interface{}) error {
 	for _, component := range components {
-		err := c.CloseOne(correlationId, component)
+		err := c.CloseOneWithContext(ctx, component)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// Closes multiple independent components concurrently.
+// Unlike Close, it closes all components even if some of them fail or hang.
+// All failures are returned as one InvocationError with descriptions of all errors in "errors" details.
+// see
+// LifecycleOptions
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  the list of components that are to be closed.
+//  - options *LifecycleOptions
+//  the parallelism limit and per-component timeout. If it is nil, all components are closed at once without timeout.
+// Returns error
+func (c *TCloser) CloseParallel(ctx context.Context, components []interface{}, options *LifecycleOptions) error {
+	return runParallel(ctx, components, options, "close", c.CloseOneWithContext)
+}
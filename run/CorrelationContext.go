@@ -0,0 +1,40 @@
+package run
+
+import "context"
+
+type correlationIdKey struct{}
+
+// Creates a context that carries the correlation id.
+// It is used to call context-aware components from code that uses correlation ids,
+// and components that accept only correlation ids from context-aware code.
+// see
+// GetCorrelationId
+// Parameters:
+//  - ctx context.Context
+//  the parent context. If it is nil the background context is used.
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns context.Context
+// a new context with the correlation id.
+func ContextWithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, correlationIdKey{}, correlationId)
+}
+
+// Gets the correlation id carried by the context.
+// see
+// ContextWithCorrelationId
+// Parameters:
+//  - ctx context.Context
+//  the context with the correlation id.
+// Returns string
+// the correlation id or empty string if the context doesn't have it.
+func GetCorrelationId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationId, _ := ctx.Value(correlationIdKey{}).(string)
+	return correlationId
+}
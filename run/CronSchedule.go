@@ -0,0 +1,218 @@
+package run
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Calendar schedule defined by a cron expression.
+
+The expression has 5 fields separated by spaces: minute (0-59), hour (0-23), day of month (1-31),
+month (1-12 or JAN-DEC) and day of week (0-6 or SUN-SAT, 7 is also Sunday). Each field can be:
+
+ *       - any value
+ 5       - exact value
+ 1-5     - range of values
+ 1,3,5   - list of values or ranges
+ 10-30/5 - every 5th value in the range
+ 0-59/15 - every 15th value, the same as "*" followed by "/15"
+
+Day of month and day of week fields can also be set to "?" that means the same as "*".
+When both day fields are restricted, the schedule is triggered on days that match either of them.
+Predefined schedules "@yearly" ("@annually"), "@monthly", "@weekly", "@daily" ("@midnight") and "@hourly" are supported as well.
+
+The schedule is calculated in the location of the time passed to Next method.
+
+see
+CronTimer
+
+Example:
+ schedule, _ := ParseCronSchedule("0,15,30,45 * * * MON-FRI")
+ next := schedule.Next(time.Now())
+
+ schedule, _ = ParseCronSchedule("0 2 * * *")
+ next = schedule.Next(time.Now().UTC())  // Every day at 02:00 UTC
+*/
+type CronSchedule struct {
+	expression string
+	minutes    uint64
+	hours      uint64
+	days       uint64
+	months     uint64
+	weekdays   uint64
+	anyDay     bool
+	anyWeekday bool
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonths = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var cronWeekdays = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+func parseCronValue(value string, min int, max int, names map[string]int) (int, bool) {
+	if number, ok := names[strings.ToUpper(value)]; ok {
+		return number, true
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < min || number > max {
+		return 0, false
+	}
+	return number, true
+}
+
+// Parses a cron field into a set of bits where each bit stands for an allowed value.
+func parseCronField(field string, min int, max int, names map[string]int) (uint64, bool) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			value, err := strconv.Atoi(part[index+1:])
+			if err != nil || value <= 0 {
+				return 0, false
+			}
+			step = value
+			part = part[:index]
+		}
+
+		start, end := min, max
+		if part != "*" && part != "?" {
+			bounds := strings.SplitN(part, "-", 2)
+			value, ok := parseCronValue(bounds[0], min, max, names)
+			if !ok {
+				return 0, false
+			}
+			start = value
+			if len(bounds) == 2 {
+				value, ok = parseCronValue(bounds[1], min, max, names)
+				if !ok || value < start {
+					return 0, false
+				}
+				end = value
+			} else if step == 1 {
+				end = start
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, true
+}
+
+// Parses a cron expression into a schedule.
+// throws
+// a ConfigError if the expression is of a wrong format.
+// Parameters:
+//  - expression string
+//  a cron expression with 5 fields or a predefined schedule like "@daily".
+// Returns *CronSchedule, error
+// a newly created CronSchedule and error.
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	value := strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[strings.ToLower(value)]; ok {
+		value = descriptor
+	}
+
+	fields := strings.Fields(value)
+	err := errors.NewConfigError("", "BAD_CRON_EXPRESSION", "Cron expression "+expression+" is in wrong format").
+		WithDetails("expression", expression)
+	if len(fields) != 5 {
+		return nil, err
+	}
+
+	c := &CronSchedule{
+		expression: expression,
+		anyDay:     fields[2] == "*" || fields[2] == "?",
+		anyWeekday: fields[4] == "*" || fields[4] == "?",
+	}
+
+	var ok [5]bool
+	c.minutes, ok[0] = parseCronField(fields[0], 0, 59, nil)
+	c.hours, ok[1] = parseCronField(fields[1], 0, 23, nil)
+	c.days, ok[2] = parseCronField(fields[2], 1, 31, nil)
+	c.months, ok[3] = parseCronField(fields[3], 1, 12, cronMonths)
+	c.weekdays, ok[4] = parseCronField(fields[4], 0, 7, cronWeekdays)
+	for _, valid := range ok {
+		if !valid {
+			return nil, err
+		}
+	}
+
+	// Sunday can be set as 0 or 7
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays = (c.weekdays | 1) &^ (1 << 7)
+	}
+
+	return c, nil
+}
+
+func (c *CronSchedule) matchDay(t time.Time) bool {
+	day := c.days&(1<<uint(t.Day())) != 0
+	weekday := c.weekdays&(1<<uint(t.Weekday())) != 0
+	if c.anyDay || c.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+// Calculates the next time the schedule is triggered.
+// Parameters:
+//  - after time.Time
+//  the time to start from. The schedule is calculated in the location of this time.
+// Returns time.Time
+// the next trigger time strictly after the specified time or zero time if the schedule is never triggered.
+func (c *CronSchedule) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Year() + 5
+
+	for t.Year() <= limit {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// Gets the cron expression of this schedule.
+// Returns string
+// the cron expression.
+func (c *CronSchedule) String() string {
+	return c.expression
+}
@@ -0,0 +1,352 @@
+package run
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Timer that is triggered by a calendar schedule defined by a cron expression.
+
+It uses the same task model and lifecycle as FixedRateTimer: it calls a callback function
+or notifies an INotifiable task, and it is controlled by Start, Stop and Close methods.
+The time is taken from IClock, which can be replaced to test schedules deterministically.
+By default the schedule is calculated in the local time zone.
+Panics in callbacks are recovered, so the timer keeps running, and the last failure is kept in LastError.
+
+see
+CronSchedule
+
+see
+FixedRateTimer
+
+see
+INotifiable
+
+Example:
+ type MyComponent struct {
+ 	timer *CronTimer
+ }
+ ...
+ func (c *MyComponent) Open(correlationId string) error {
+ 	...
+ 	c.timer, _ = NewCronTimerFromCallback(func() { c.cleanup() }, "0 2 * * *")
+ 	c.timer.SetLocation(time.UTC)
+ 	c.timer.Start()
+ 	...
+ }
+
+ func (c *MyComponent) Close(correlationId string) error {
+ 	...
+ 	c.timer.Stop()
+ 	...
+ }
+*/
+type CronTimer struct {
+	task      INotifiable
+	callback  func()
+	schedule  *CronSchedule
+	clock     IClock
+	location  *time.Location
+	stop      chan struct{}
+	lastError error
+	lock      sync.Mutex
+}
+
+// Creates new instance of the timer.
+// Returns *CronTimer
+func NewCronTimer() *CronTimer {
+	return &CronTimer{
+		clock:    NewRealClock(),
+		location: time.Local,
+	}
+}
+
+// Creates new instance of the timer and sets its values.
+// throws
+// a ConfigError if the schedule is of a wrong format.
+// Parameters:
+//  - callback func()
+//  callback function to call when timer is triggered.
+//  - schedule string
+//  a cron expression that defines when the timer is triggered.
+// Returns *CronTimer, error
+func NewCronTimerFromCallback(callback func(), schedule string) (*CronTimer, error) {
+	c := NewCronTimer()
+	c.SetCallback(callback)
+	err := c.SetSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Creates new instance of the timer and sets its values.
+// throws
+// a ConfigError if the schedule is of a wrong format.
+// Parameters:
+//  - task INotifiable
+//  Notifiable object to call when timer is triggered.
+//  - schedule string
+//  a cron expression that defines when the timer is triggered.
+// Returns *CronTimer, error
+func NewCronTimerFromTask(task INotifiable, schedule string) (*CronTimer, error) {
+	c := NewCronTimer()
+	c.SetTask(task)
+	err := c.SetSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Gets the INotifiable object that receives notifications from this timer.
+// Returns INotifiable
+// the INotifiable object or nil if it is not set.
+func (c *CronTimer) Task() INotifiable {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.task
+}
+
+// Sets a new INotifiable object to receive notifications from this timer.
+// Parameters:
+//  - value INotifiable
+//  a INotifiable object to be triggered.
+func (c *CronTimer) SetTask(value INotifiable) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.task = value
+	c.callback = func() {
+		value.Notify("timer", NewEmptyParameters())
+	}
+}
+
+// Gets the callback function that is called when this timer is triggered.
+// Returns func()
+// the callback function or nil if it is not set.
+func (c *CronTimer) Callback() func() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.callback
+}
+
+// Sets the callback function that is called when this timer is triggered.
+// Parameters:
+//  - value func()
+//  the callback function to be called.
+func (c *CronTimer) SetCallback(value func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.callback = value
+	c.task = nil
+}
+
+// Gets the schedule that defines when the timer is triggered.
+// Returns *CronSchedule
+// the schedule or nil if it is not set.
+func (c *CronTimer) Schedule() *CronSchedule {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.schedule
+}
+
+// Sets the schedule that defines when the timer is triggered.
+// The change takes effect when the timer is started next time.
+// throws
+// a ConfigError if the schedule is of a wrong format.
+// Parameters:
+//  - value string
+//  a cron expression.
+// Returns error
+func (c *CronTimer) SetSchedule(value string) error {
+	schedule, err := ParseCronSchedule(value)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.schedule = schedule
+	return nil
+}
+
+// Gets the clock used to calculate trigger times.
+// Returns IClock
+// the clock.
+func (c *CronTimer) Clock() IClock {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.getClock()
+}
+
+func (c *CronTimer) getClock() IClock {
+	if c.clock == nil {
+		c.clock = NewRealClock()
+	}
+	return c.clock
+}
+
+// Sets the clock used to calculate trigger times.
+// The change takes effect when the timer is started next time.
+// Parameters:
+//  - value IClock
+//  the clock to be used.
+func (c *CronTimer) SetClock(value IClock) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clock = value
+}
+
+// Gets the time zone the schedule is calculated in.
+// Returns *time.Location
+// the time zone.
+func (c *CronTimer) Location() *time.Location {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.getLocation()
+}
+
+func (c *CronTimer) getLocation() *time.Location {
+	if c.location == nil {
+		c.location = time.Local
+	}
+	return c.location
+}
+
+// Sets the time zone the schedule is calculated in.
+// The change takes effect when the timer is started next time.
+// Parameters:
+//  - value *time.Location
+//  the time zone, for instance time.UTC.
+func (c *CronTimer) SetLocation(value *time.Location) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.location = value
+}
+
+// Checks if the timer is started.
+// Returns bool
+// true if the timer is started and false if it is stopped.
+func (c *CronTimer) IsStarted() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stop != nil
+}
+
+// Gets the error of the last failed callback call.
+// Returns error
+// the error or nil if callbacks did not fail.
+func (c *CronTimer) LastError() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lastError
+}
+
+// Starts the timer.
+// The timer is triggered at times defined by the schedule until it is stopped.
+func (c *CronTimer) Start() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Stop previously started timer
+	c.stopTimer()
+
+	// Exit if schedule is not defined
+	if c.schedule == nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	c.stop = stop
+
+	go c.run(stop, c.schedule, c.getClock(), c.getLocation())
+}
+
+func (c *CronTimer) run(stop chan struct{}, schedule *CronSchedule, clock IClock, location *time.Location) {
+	for {
+		now := clock.Now()
+		next := schedule.Next(now.In(location))
+		if next.IsZero() {
+			return
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-clock.After(next.Sub(now)):
+		}
+
+		c.lock.Lock()
+		callback := c.callback
+		stopped := c.stop != stop
+		c.lock.Unlock()
+
+		if stopped {
+			return
+		}
+		if callback != nil {
+			c.call(callback)
+		}
+	}
+}
+
+func (c *CronTimer) call(callback func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			message := convert.StringConverter.ToString(r)
+			err := errors.NewInvocationError(
+				"timer",
+				"TIMER_FAILED",
+				fmt.Sprintf("Timer callback failed: %s", message),
+			)
+
+			c.lock.Lock()
+			c.lastError = err
+			c.lock.Unlock()
+		}
+	}()
+	callback()
+}
+
+func (c *CronTimer) stopTimer() {
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
+	}
+}
+
+// Stops the timer.
+func (c *CronTimer) Stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stopTimer()
+}
+
+// Closes the timer.
+// This is required by ICloseable interface, but besides that it is identical to Stop().
+// Parameters:
+//  - correlationId: string
+//  transaction id to trace execution through call chain.
+// Returns error
+func (c *CronTimer) Close(correlationId string) error {
+	c.Stop()
+	return nil
+}
@@ -1,5 +1,7 @@
 package run
 
+import "context"
+
 /*
  Helper class that executes components.
 */
@@ -19,9 +21,34 @@ var Executor *TExecutor = &TExecutor{}
 // Returns []interface{}, error
 // execution result or error
 func (c *TExecutor) ExecuteOne(correlationId string, component interface{}, args *Parameters) (interface{}, error) {
-	v, ok := component.(IExecutable)
-	if ok {
-		return v.Execute(correlationId, args)
+	return c.ExecuteOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Executes specific component using the context.
+// Components that implement IContextExecutable interface receive the context,
+// components that implement IExecutable interface receive the correlation id carried by the context.
+// see
+// IContextExecutable
+// see
+// IExecutable
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error
+func (c *TExecutor) ExecuteOneWithContext(ctx context.Context, component interface{}, args *Parameters) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if v, ok := component.(IContextExecutable); ok {
+		return v.Execute(ctx, args)
+	}
+	if v, ok := component.(IExecutable); ok {
+		return v.Execute(GetCorrelationId(ctx), args)
 	}
 	return nil, nil
 }
@@ -39,10 +66,27 @@ func (c *TExecutor) ExecuteOne(correlationId string, component interface{}, args
 // Returns []interface{}, error
 // execution result or error
 func (c *TExecutor) Execute(correlationId string, components []interface{}, args *Parameters) ([]interface{}, error) {
+	return c.ExecuteWithContext(ContextWithCorrelationId(context.Background(), correlationId), components, args)
+}
+
+// Executes multiple components using the context.
+// Execution stops when the context is cancelled.
+// see
+// ExecuteOneWithContext
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  a list of components that are to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns []interface{}, error
+// execution result or error
+func (c *TExecutor) ExecuteWithContext(ctx context.Context, components []interface{}, args *Parameters) ([]interface{}, error) {
 	results := make([]interface{}, 0, 5)
 
 	for _, component := range components {
-		result, err := c.ExecuteOne(correlationId, component, args)
+		result, err := c.ExecuteOneWithContext(ctx, component, args)
 		if err != nil {
 			return results, err
 		}
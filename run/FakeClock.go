@@ -0,0 +1,151 @@
+package run
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+Clock that is controlled manually. It is used in tests to fast-forward timers without waiting.
+
+The time changes only when Advance or Set methods are called. Channels returned by After
+receive the time when the clock is moved to or past their deadlines.
+
+see
+IClock
+
+see
+FixedRateTimer
+
+see
+CronTimer
+
+Example:
+ clock := NewFakeClock(time.Date(2024, time.March, 15, 1, 30, 0, 0, time.UTC))
+ timer := NewFixedRateTimerFromCallback(func() { counter++ }, 1000, 0)
+ timer.SetClock(clock)
+ timer.Start()
+
+ clock.BlockUntil(1)
+ clock.Advance(time.Second)  // The timer is triggered
+*/
+type FakeClock struct {
+	now     time.Time
+	waiters []*fakeClockWaiter
+	lock    sync.Mutex
+	changed *sync.Cond
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+// Creates a new instance of the fake clock and sets its time.
+// Parameters:
+//  - now time.Time
+//  the initial time of the clock.
+// Returns *FakeClock
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{
+		now:     now,
+		waiters: []*fakeClockWaiter{},
+	}
+	c.changed = sync.NewCond(&c.lock)
+	return c
+}
+
+// Gets the current time of the clock.
+// Returns time.Time
+// the current time.
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// Returns a channel that receives the time when the clock is moved by the duration.
+// Parameters:
+//  - duration time.Duration
+//  the duration to wait.
+// Returns <-chan time.Time
+// the channel that receives the time when the duration elapses.
+func (c *FakeClock) After(duration time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	channel := make(chan time.Time, 1)
+	if duration <= 0 {
+		channel <- c.now
+		return channel
+	}
+
+	c.waiters = append(c.waiters, &fakeClockWaiter{
+		deadline: c.now.Add(duration),
+		channel:  channel,
+	})
+	c.changed.Broadcast()
+	return channel
+}
+
+// Moves the clock forward by the duration.
+// Parameters:
+//  - duration time.Duration
+//  the duration to move the clock by.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.setTime(c.now.Add(duration))
+}
+
+// Sets the clock time. Moving the clock backward doesn't trigger any waiters.
+// Parameters:
+//  - now time.Time
+//  the new time of the clock.
+func (c *FakeClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.setTime(now)
+}
+
+func (c *FakeClock) setTime(now time.Time) {
+	c.now = now
+
+	waiters := make([]*fakeClockWaiter, 0, len(c.waiters))
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(now) {
+			waiters = append(waiters, waiter)
+		} else {
+			waiter.channel <- now
+		}
+	}
+	c.waiters = waiters
+	c.changed.Broadcast()
+}
+
+// Gets the number of channels returned by After that are waiting for their deadlines.
+// Returns int
+// the number of waiters.
+func (c *FakeClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.waiters)
+}
+
+// Blocks until the specified number of channels are waiting for their deadlines.
+// It is used to make sure timers started in other goroutines are waiting before the clock is moved.
+// Parameters:
+//  - count int
+//  the number of waiters to wait for.
+func (c *FakeClock) BlockUntil(count int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for len(c.waiters) < count {
+		c.changed.Wait()
+	}
+}
@@ -1,7 +1,13 @@
 package run
 
 import (
+	"fmt"
+	"math/rand"
+	"sync"
 	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
 )
 
 /*
@@ -9,6 +15,18 @@ Timer that is triggered in equal time intervals.
 
 It has summetric cross-language implementation and is often used by Pip.Services toolkit to perform periodic processing and cleanup in microservices.
 
+The timer is safe for concurrent use. Callbacks are called in separate goroutines,
+and the overlap policy defines what happens when the timer is triggered while the previous callback is still running.
+Optional jitter delays each triggering by a random time to spread the load from many timers.
+Panics in callbacks are recovered and reported in the timer statistics together with callback timings.
+The time is taken from IClock, which can be replaced to fast-forward the timer in tests.
+
+see
+TimerOverlapPolicy
+
+see
+TimerStats
+
 see
 INotifiable
 
@@ -36,7 +54,12 @@ type FixedRateTimer struct {
 	callback func()
 	delay    int
 	interval int
-	ticker   *time.Ticker
+	jitter   int
+	overlap  TimerOverlapPolicy
+	clock    IClock
+	stop     chan struct{}
+	stats    TimerStats
+	lock     sync.Mutex
 }
 
 // Creates new instance of the timer and sets its values.
@@ -84,6 +107,9 @@ func NewFixedRateTimerFromTask(task INotifiable, interval int, delay int) *Fixed
 // Returns INotifiable
 // the INotifiable object or null if it is not set.
 func (c *FixedRateTimer) Task() INotifiable {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.task
 }
 
@@ -92,9 +118,12 @@ func (c *FixedRateTimer) Task() INotifiable {
 //  - value INotifiable
 //  a INotifiable object to be triggered.
 func (c *FixedRateTimer) SetTask(value INotifiable) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.task = value
 	c.callback = func() {
-		c.task.Notify("timer", NewEmptyParameters())
+		value.Notify("timer", NewEmptyParameters())
 	}
 }
 
@@ -103,6 +132,9 @@ func (c *FixedRateTimer) SetTask(value INotifiable) {
 // the callback function or null if it is not set.
 // Returns func()
 func (c *FixedRateTimer) Callback() func() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.callback
 }
 
@@ -111,6 +143,9 @@ func (c *FixedRateTimer) Callback() func() {
 //  - value func()
 //  the callback function to be called.
 func (c *FixedRateTimer) SetCallback(value func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.callback = value
 	c.task = nil
 }
@@ -119,6 +154,9 @@ func (c *FixedRateTimer) SetCallback(value func()) {
 // Returns number
 // the delay in milliseconds.
 func (c *FixedRateTimer) Delay() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.delay
 }
 
@@ -127,6 +165,9 @@ func (c *FixedRateTimer) Delay() int {
 //  - value int
 //  a delay in milliseconds.
 func (c *FixedRateTimer) SetDelay(value int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.delay = value
 }
 
@@ -134,6 +175,9 @@ func (c *FixedRateTimer) SetDelay(value int) {
 // Returns number
 // the interval in milliseconds
 func (c *FixedRateTimer) Interval() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	return c.interval
 }
 
@@ -142,57 +186,254 @@ func (c *FixedRateTimer) Interval() int {
 //  - value int
 //  an interval in milliseconds.
 func (c *FixedRateTimer) SetInterval(value int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	c.interval = value
 }
 
+// Gets maximum random delay added to each triggering.
+// Returns int
+// the jitter in milliseconds.
+func (c *FixedRateTimer) Jitter() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.jitter
+}
+
+// Sets maximum random delay added to each triggering.
+// The jitter doesn't accumulate, so the timer keeps its average rate.
+// The change takes effect when the timer is started next time.
+// Parameters:
+//  - value int
+//  a jitter in milliseconds.
+func (c *FixedRateTimer) SetJitter(value int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.jitter = value
+}
+
+// Gets the policy that defines what happens when the timer is triggered while the previous callback is still running.
+// Returns TimerOverlapPolicy
+// the overlap policy.
+func (c *FixedRateTimer) Overlap() TimerOverlapPolicy {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.overlap
+}
+
+// Sets the policy that defines what happens when the timer is triggered while the previous callback is still running.
+// Parameters:
+//  - value TimerOverlapPolicy
+//  the overlap policy.
+func (c *FixedRateTimer) SetOverlap(value TimerOverlapPolicy) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.overlap = value
+}
+
+// Gets the clock used to trigger the timer.
+// Returns IClock
+// the clock.
+func (c *FixedRateTimer) Clock() IClock {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.getClock()
+}
+
+func (c *FixedRateTimer) getClock() IClock {
+	if c.clock == nil {
+		c.clock = NewRealClock()
+	}
+	return c.clock
+}
+
+// Sets the clock used to trigger the timer.
+// The change takes effect when the timer is started next time.
+// Parameters:
+//  - value IClock
+//  the clock to be used.
+func (c *FixedRateTimer) SetClock(value IClock) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clock = value
+}
+
+// Gets statistics of callbacks called by this timer.
+// Returns TimerStats
+// a copy of the timer statistics.
+func (c *FixedRateTimer) Stats() TimerStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stats
+}
+
 // Checks if the timer is started.
 // Returns bool
 // true if the timer is started and false if it is stopped.
 func (c *FixedRateTimer) IsStarted() bool {
-	return c.ticker != nil
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.stop != nil
 }
 
 // Starts the timer.
 // Initially the timer is triggered after delay. After that it is triggered after interval until it is stopped.
 func (c *FixedRateTimer) Start() {
-	// Todo: lock to avoid concurrency issues
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
 	// Stop previously set timer
-	c.Stop()
+	c.stopTimer()
 
 	// Exit if interval is not defined
 	if c.interval <= 0 {
 		return
 	}
 
-	// Introducing delay
-	delay := c.delay - c.interval
-	ticker := time.NewTicker(time.Millisecond * time.Duration(c.interval))
-	c.ticker = ticker
+	stop := make(chan struct{})
+	c.stop = stop
+
+	interval := time.Millisecond * time.Duration(c.interval)
+	delay := interval
+	if c.delay > 0 {
+		delay = time.Millisecond * time.Duration(c.delay)
+	}
+
+	go c.run(stop, c.getClock(), delay, interval, time.Millisecond*time.Duration(c.jitter))
+}
 
-	go func() {
-		if delay > 0 {
-			time.Sleep(time.Millisecond * time.Duration(delay))
+func (c *FixedRateTimer) run(stop chan struct{}, clock IClock, delay time.Duration, interval time.Duration, jitter time.Duration) {
+	next := clock.Now().Add(delay)
+
+	for {
+		wait := next.Sub(clock.Now())
+		if jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(jitter)))
 		}
 
-		for range ticker.C {
-			callback := c.callback
-			if callback != nil {
-				callback()
-			}
+		select {
+		case <-stop:
+			return
+		case <-clock.After(wait):
+		}
+
+		c.trigger(stop)
+
+		// Missed triggers are dropped after stalls, so the timer doesn't fire them back-to-back
+		next = next.Add(interval)
+		if now := clock.Now(); !next.After(now) {
+			next = next.Add((now.Sub(next)/interval + 1) * interval)
+		}
+	}
+}
+
+// Starts the callback according to the overlap policy.
+func (c *FixedRateTimer) trigger(stop chan struct{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.stop != stop || c.callback == nil {
+		return
+	}
+
+	c.stats.Triggered++
+	if c.stats.Running > 0 {
+		switch c.overlap {
+		case OverlapSkip:
+			c.stats.Skipped++
+			return
+		case OverlapQueue:
+			c.stats.Queued++
+			return
+		}
+	}
+
+	c.stats.Running++
+	go c.execute(stop, c.getClock(), c.callback)
+}
+
+// Calls the callback and then all callbacks queued while it was running.
+func (c *FixedRateTimer) execute(stop chan struct{}, clock IClock, callback func()) {
+	for {
+		c.call(clock, callback)
+
+		c.lock.Lock()
+		if c.stats.Queued > 0 && c.stop == stop && c.callback != nil {
+			c.stats.Queued--
+			callback = c.callback
+			c.lock.Unlock()
+			continue
+		}
+		if c.stop != stop {
+			c.stats.Queued = 0
 		}
+		c.stats.Running--
+		c.lock.Unlock()
+		return
+	}
+}
+
+func (c *FixedRateTimer) call(clock IClock, callback func()) {
+	start := clock.Now()
+	var err error
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := convert.StringConverter.ToString(r)
+				err = errors.NewInvocationError(
+					"timer",
+					"TIMER_FAILED",
+					fmt.Sprintf("Timer callback failed: %s", message),
+				)
+			}
+		}()
+		callback()
 	}()
+
+	duration := clock.Now().Sub(start)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stats.Completed++
+	c.stats.LastStart = start
+	c.stats.LastDuration = duration
+	c.stats.TotalDuration += duration
+	if duration > c.stats.MaxDuration {
+		c.stats.MaxDuration = duration
+	}
+	if err != nil {
+		c.stats.Failed++
+		c.stats.LastError = err
+	}
 }
 
-//Stops the timer.
-func (c *FixedRateTimer) Stop() {
-	// Todo: lock to avoid concurrency
-	ticker := c.ticker
-	if ticker != nil {
-		ticker.Stop()
-		c.ticker = nil
+func (c *FixedRateTimer) stopTimer() {
+	if c.stop != nil {
+		close(c.stop)
+		c.stop = nil
 	}
 }
 
+// Stops the timer.
+// Callbacks that are running at the moment are not interrupted, but queued callbacks are discarded.
+func (c *FixedRateTimer) Stop() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.stopTimer()
+}
+
 // Closes the timer.
 // This is required by ICloseable interface, but besides that it is identical to stop().
 // Parameters:
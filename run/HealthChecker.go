@@ -0,0 +1,220 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+)
+
+/*
+Health of one component in the health report.
+
+name - A component name
+status - HealthUp, HealthDegraded or HealthDown status
+details - Additional information about the component state
+error - An error message when the check failed or timed out
+duration - Duration of the check in milliseconds
+*/
+type ComponentHealth struct {
+	Name     string                 `json:"name"`
+	Status   string                 `json:"status"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+	Duration int64                  `json:"duration"`
+}
+
+/*
+Report with health of multiple components.
+
+status - Overall status: HealthDown if any component is down, HealthDegraded if any component is degraded and HealthUp otherwise
+components - Health of the checked components
+time - Time when the check was started
+duration - Duration of all checks in milliseconds
+*/
+type HealthReport struct {
+	Status     string             `json:"status"`
+	Components []*ComponentHealth `json:"components"`
+	Time       time.Time          `json:"time"`
+	Duration   int64              `json:"duration"`
+}
+
+// Converts the report into JSON string.
+// Returns string, error
+// JSON string with the report, and error.
+func (c *HealthReport) ToJson() (string, error) {
+	return convert.ToJson(c)
+}
+
+/*
+Helper class that checks health of components.
+
+Components that implement IHealthCheckable interface are checked by their CheckHealth method.
+Components that implement only IOpenable interface are up when they are opened and down otherwise.
+Other components are not included into the report.
+Checks run concurrently, and checks that don't complete in time are reported as down.
+
+see
+IHealthCheckable
+
+Example:
+ report := HealthChecker.Check(ctx, references.GetAll(), 5*time.Second)
+ json, _ := report.ToJson()
+*/
+type THealthChecker struct{}
+
+var HealthChecker *THealthChecker = &THealthChecker{}
+
+// Checks if the component health can be checked.
+func isHealthCheckable(component interface{}) bool {
+	switch component.(type) {
+	case IHealthCheckable, IOpenable, IContextOpenable:
+		return true
+	}
+	return false
+}
+
+// Checks health of specific component.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - name string
+//  the component name in the report.
+//  - component interface{}
+//  the component that is to be checked.
+//  - timeout time.Duration
+//  a maximum time to check the component (0 for no timeout).
+// Returns *ComponentHealth
+// the component health or nil if the component health can't be checked.
+func (c *THealthChecker) CheckOne(ctx context.Context, name string, component interface{}, timeout time.Duration) *ComponentHealth {
+	if !isHealthCheckable(component) {
+		return nil
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan *ComponentHealth, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &ComponentHealth{
+					Status: HealthDown,
+					Error:  "Health check failed: " + convert.StringConverter.ToString(r),
+				}
+			}
+		}()
+
+		health := &ComponentHealth{Status: HealthDown}
+		if v, ok := component.(IHealthCheckable); ok {
+			result := v.CheckHealth(ctx)
+			if result != nil {
+				health.Status = result.Status
+				health.Details = result.Details
+			}
+		} else if Opener.IsOpenOne(component) {
+			health.Status = HealthUp
+		}
+		done <- health
+	}()
+
+	var health *ComponentHealth
+	select {
+	case health = <-done:
+	case <-ctx.Done():
+		health = &ComponentHealth{
+			Status: HealthDown,
+			Error:  fmt.Sprintf("Health check was interrupted: %v", ctx.Err()),
+		}
+	}
+
+	health.Name = name
+	health.Duration = time.Since(start).Milliseconds()
+	return health
+}
+
+// Checks health of multiple components concurrently. Components are named by their types.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  a list of components that are to be checked.
+//  - timeout time.Duration
+//  a maximum time to check one component (0 for no timeout).
+// Returns *HealthReport
+// the health report.
+func (c *THealthChecker) Check(ctx context.Context, components []interface{}, timeout time.Duration) *HealthReport {
+	names := make([]string, len(components))
+	for index, component := range components {
+		names[index] = fmt.Sprintf("%T", component)
+	}
+	return c.check(ctx, names, components, timeout)
+}
+
+// Checks health of named components concurrently.
+// Components in the report are ordered by their names.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components map[string]interface{}
+//  components that are to be checked by their names.
+//  - timeout time.Duration
+//  a maximum time to check one component (0 for no timeout).
+// Returns *HealthReport
+// the health report.
+func (c *THealthChecker) CheckNamed(ctx context.Context, components map[string]interface{}, timeout time.Duration) *HealthReport {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]interface{}, len(names))
+	for index, name := range names {
+		values[index] = components[name]
+	}
+	return c.check(ctx, names, values, timeout)
+}
+
+func (c *THealthChecker) check(ctx context.Context, names []string, components []interface{}, timeout time.Duration) *HealthReport {
+	start := time.Now()
+	results := make([]*ComponentHealth, len(components))
+
+	var wait sync.WaitGroup
+	for index, component := range components {
+		wait.Add(1)
+		go func(index int, component interface{}) {
+			defer wait.Done()
+			results[index] = c.CheckOne(ctx, names[index], component, timeout)
+		}(index, component)
+	}
+	wait.Wait()
+
+	report := &HealthReport{
+		Status:     HealthUp,
+		Components: []*ComponentHealth{},
+		Time:       start,
+	}
+	for _, health := range results {
+		if health == nil {
+			continue
+		}
+		report.Components = append(report.Components, health)
+
+		if health.Status == HealthDown {
+			report.Status = HealthDown
+		} else if health.Status != HealthUp && report.Status == HealthUp {
+			report.Status = HealthDegraded
+		}
+	}
+	report.Duration = time.Since(start).Milliseconds()
+
+	return report
+}
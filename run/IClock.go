@@ -0,0 +1,64 @@
+package run
+
+import "time"
+
+/*
+Interface for clocks that provide current time and wait for time intervals.
+
+Timers get time from the clock instead of calling time package directly,
+so schedules can be tested deterministically by replacing the clock.
+
+see
+RealClock
+
+see
+FakeClock
+
+see
+CronTimer
+*/
+type IClock interface {
+	// Gets the current time.
+	// Returns time.Time
+	// the current time.
+	Now() time.Time
+
+	// Waits for the duration to elapse and then sends the current time on the returned channel.
+	// Parameters:
+	//  - duration time.Duration
+	//  the duration to wait.
+	// Returns <-chan time.Time
+	// the channel that receives the time when the duration elapses.
+	After(duration time.Duration) <-chan time.Time
+}
+
+/*
+Clock that uses the system time.
+
+see
+IClock
+*/
+type RealClock struct{}
+
+// Creates a new instance of the system clock.
+// Returns *RealClock
+func NewRealClock() *RealClock {
+	return &RealClock{}
+}
+
+// Gets the current system time.
+// Returns time.Time
+// the current time.
+func (c *RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// Waits for the duration to elapse and then sends the current time on the returned channel.
+// Parameters:
+//  - duration time.Duration
+//  the duration to wait.
+// Returns <-chan time.Time
+// the channel that receives the time when the duration elapses.
+func (c *RealClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
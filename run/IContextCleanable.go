@@ -0,0 +1,21 @@
+package run
+
+import "context"
+
+/*
+Context-aware variant of ICleanable interface for components that should clean their state.
+
+see
+ICleanable
+
+see
+Cleaner
+*/
+type IContextCleanable interface {
+	// Clears component state.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	// Returns error
+	Clear(ctx context.Context) error
+}
@@ -0,0 +1,38 @@
+package run
+
+import "context"
+
+/*
+Context-aware variant of IClosable interface for components that require explicit closure.
+
+The context allows to cancel a slow closure, set a deadline or pass tracing values.
+The correlation id can be retrieved from the context with GetCorrelationId.
+
+see
+IClosable
+
+see
+Closer
+
+Example:
+ type MyConnector struct {
+ 	_client interface{}
+ }
+
+ func (c *MyConnector) Close(ctx context.Context) error {
+ 	if c._client != nil {
+ 		err := c._client.CloseContext(ctx)
+ 		c._client = nil
+ 		return err
+ 	}
+ 	return nil
+ }
+*/
+type IContextClosable interface {
+	// Closes the component and frees used resources.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	// Returns error
+	Close(ctx context.Context) error
+}
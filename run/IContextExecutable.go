@@ -0,0 +1,34 @@
+package run
+
+import "context"
+
+/*
+Context-aware variant of IExecutable interface for components that can be called to execute work.
+
+see
+IExecutable
+
+see
+Executor
+
+Example:
+ type EchoComponent struct{}
+
+ func (c *EchoComponent) Execute(ctx context.Context, args *Parameters) (result interface{}, err error) {
+ 	if err = ctx.Err(); err != nil {
+ 		return nil, err
+ 	}
+ 	return args.Get("message"), nil
+ }
+*/
+type IContextExecutable interface {
+	// Executes component with arguments and receives execution result.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	//  - args *Parameters
+	//  execution arguments.
+	// Returns interface{}, error
+	// result or execution and error
+	Execute(ctx context.Context, args *Parameters) (result interface{}, err error)
+}
@@ -0,0 +1,22 @@
+package run
+
+import "context"
+
+/*
+Context-aware variant of INotifiable interface for components that can be asynchronously notified.
+
+see
+INotifiable
+
+see
+Notifier
+*/
+type IContextNotifiable interface {
+	// Notifies the component about occured event.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	//  - args *Parameters
+	//  notification arguments.
+	Notify(ctx context.Context, args *Parameters)
+}
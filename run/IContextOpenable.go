@@ -0,0 +1,51 @@
+package run
+
+import "context"
+
+/*
+Context-aware variant of IOpenable interface for components that require explicit opening and closing.
+
+The context allows to cancel a slow opening, set a deadline or pass tracing values.
+The correlation id can be retrieved from the context with GetCorrelationId.
+
+see
+IOpenable
+
+see
+Opener
+
+Example:
+ type MyPersistence struct {
+ 	_client interface{}
+ }
+
+ func (c *MyPersistence) IsOpen() bool {
+ 	return c._client != nil
+ }
+
+ func (c *MyPersistence) Open(ctx context.Context) error {
+ 	if c.IsOpen() {
+ 		return nil
+ 	}
+ 	client, err := ConnectContext(ctx)
+ 	c._client = client
+ 	return err
+ }
+
+ func (c *MyPersistence) Close(ctx context.Context) error {
+ 	...
+ }
+*/
+type IContextOpenable interface {
+	IContextClosable
+	// Checks if the component is opened.
+	// Returns bool
+	// true if the component has been opened and false otherwise.
+	IsOpen() bool
+	// Opens the component.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	// Returns error
+	Open(ctx context.Context) error
+}
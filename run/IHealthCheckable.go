@@ -0,0 +1,64 @@
+package run
+
+import "context"
+
+// The component works normally.
+const HealthUp = "up"
+
+// The component works with limited functionality or performance.
+const HealthDegraded = "degraded"
+
+// The component doesn't work.
+const HealthDown = "down"
+
+/*
+Result of a component health check.
+
+status - HealthUp, HealthDegraded or HealthDown status
+details - Additional information about the component state, for instance a number of connections
+*/
+type HealthCheckResult struct {
+	Status  string                 `json:"status"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Creates a new health check result.
+// Parameters:
+//  - status string
+//  HealthUp, HealthDegraded or HealthDown status.
+//  - details map[string]interface{}
+//  additional information about the component state.
+// Returns *HealthCheckResult
+func NewHealthCheckResult(status string, details map[string]interface{}) *HealthCheckResult {
+	return &HealthCheckResult{
+		Status:  status,
+		Details: details,
+	}
+}
+
+/*
+Interface for components that can check their health.
+
+see
+HealthChecker
+
+Example:
+ func (c *MyPersistence) CheckHealth(ctx context.Context) *HealthCheckResult {
+ 	if c.client == nil {
+ 		return NewHealthCheckResult(HealthDown, nil)
+ 	}
+ 	if err := c.client.Ping(ctx); err != nil {
+ 		return NewHealthCheckResult(HealthDegraded, map[string]interface{}{"error": err.Error()})
+ 	}
+ 	return NewHealthCheckResult(HealthUp, nil)
+ }
+*/
+type IHealthCheckable interface {
+	// Checks the component health.
+	// Parameters:
+	//  - ctx context.Context
+	//  the context with cancellation, deadline and correlation id.
+	// Returns *HealthCheckResult
+	// the health check result.
+	CheckHealth(ctx context.Context) *HealthCheckResult
+}
@@ -0,0 +1,152 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Options to open or close independent components concurrently.
+
+parallelism - A maximum number of components processed at the same time (0 for no limit)
+timeout - A maximum time to open or close one component (0 for no timeout)
+
+When a component doesn't finish in time, the call returns an error without waiting for it,
+but components that ignore the context can't be interrupted and keep running in the background.
+
+see
+Opener
+
+see
+Closer
+
+Example:
+ options := NewLifecycleOptions(10, 30*time.Second)
+ err := Opener.OpenParallel(ctx, components, options)
+*/
+type LifecycleOptions struct {
+	Parallelism int
+	Timeout     time.Duration
+}
+
+// Creates new lifecycle options.
+// Parameters:
+//  - parallelism int
+//  a maximum number of components processed at the same time (0 for no limit).
+//  - timeout time.Duration
+//  a maximum time to open or close one component (0 for no timeout).
+// Returns *LifecycleOptions
+func NewLifecycleOptions(parallelism int, timeout time.Duration) *LifecycleOptions {
+	return &LifecycleOptions{
+		Parallelism: parallelism,
+		Timeout:     timeout,
+	}
+}
+
+// Calls the action for one component with a timeout.
+// Panics are returned as InvocationError, since they can't be handled by the caller in another goroutine.
+func callWithTimeout(ctx context.Context, timeout time.Duration, operation string,
+	component interface{}, action func(ctx context.Context, component interface{}) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	correlationId := GetCorrelationId(ctx)
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				message := convert.StringConverter.ToString(r)
+				done <- errors.NewInvocationError(
+					correlationId,
+					strings.ToUpper(operation)+"_FAILED",
+					fmt.Sprintf("Failed to %s %T: %s", operation, component, message),
+				).WithDetails("component", fmt.Sprintf("%T", component))
+			}
+		}()
+		done <- action(ctx, component)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return errors.NewInvocationError(
+				correlationId,
+				strings.ToUpper(operation)+"_TIMEOUT",
+				fmt.Sprintf("Failed to %s %T in %v", operation, component, timeout),
+			).WithDetails("component", fmt.Sprintf("%T", component)).WithDetails("timeout", timeout.Milliseconds())
+		}
+		return ctx.Err()
+	}
+}
+
+// Calls the action for all components concurrently and collects all failures into one error.
+func runParallel(ctx context.Context, components []interface{}, options *LifecycleOptions, operation string,
+	action func(ctx context.Context, component interface{}) error) error {
+	if options == nil {
+		options = &LifecycleOptions{}
+	}
+
+	parallelism := options.Parallelism
+	if parallelism <= 0 || parallelism > len(components) {
+		parallelism = len(components)
+	}
+
+	failures := make([]error, len(components))
+	slots := make(chan struct{}, parallelism)
+	var wait sync.WaitGroup
+
+	for index, component := range components {
+		slots <- struct{}{}
+		wait.Add(1)
+		go func(index int, component interface{}) {
+			defer func() {
+				<-slots
+				wait.Done()
+			}()
+			failures[index] = callWithTimeout(ctx, options.Timeout, operation, component, action)
+		}(index, component)
+	}
+	wait.Wait()
+
+	return aggregateErrors(GetCorrelationId(ctx), operation, failures)
+}
+
+// Combines errors into one InvocationError with descriptions of all errors in "errors" details.
+// The first error is set as the cause.
+func aggregateErrors(correlationId string, operation string, failures []error) error {
+	descriptions := []*errors.ErrorDescription{}
+	messages := []string{}
+	var firstErr error
+
+	for _, err := range failures {
+		if err == nil {
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		descriptions = append(descriptions, errors.NewErrorDescription(err))
+		messages = append(messages, err.Error())
+	}
+
+	if firstErr == nil {
+		return nil
+	}
+
+	return errors.NewInvocationError(
+		correlationId,
+		strings.ToUpper(operation)+"_FAILED",
+		fmt.Sprintf("Failed to %s %d of %d components: %s", operation, len(messages), len(failures), strings.Join(messages, "; ")),
+	).WithDetails("errors", descriptions).WithCause(firstErr)
+}
@@ -1,5 +1,7 @@
 package run
 
+import "context"
+
 /*
 Helper class that notifies components.
 */
@@ -19,9 +21,31 @@ var Notifier *TNotifier = &TNotifier{}
 //  - args *Parameters
 //  notifiation arguments.
 func (c *TNotifier) NotifyOne(correlationId string, component interface{}, args *Parameters) {
-	v, ok := component.(INotifiable)
-	if ok {
-		v.Notify(correlationId, args)
+	c.NotifyOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Notifies specific component using the context.
+// Components that implement IContextNotifiable interface receive the context,
+// components that implement INotifiable interface receive the correlation id carried by the context.
+// see
+// IContextNotifiable
+// see
+// INotifiable
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be notified.
+//  - args *Parameters
+//  notification arguments.
+func (c *TNotifier) NotifyOneWithContext(ctx context.Context, component interface{}, args *Parameters) {
+	if ctx.Err() != nil {
+		return
+	}
+	if v, ok := component.(IContextNotifiable); ok {
+		v.Notify(ctx, args)
+	} else if v, ok := component.(INotifiable); ok {
+		v.Notify(GetCorrelationId(ctx), args)
 	}
 }
 
@@ -39,7 +63,22 @@ func (c *TNotifier) NotifyOne(correlationId string, component interface{}, args
 // 			- args *Parameters
 // 			notification arguments.
 func (c *TNotifier) Notify(correlationId string, components []interface{}, args *Parameters) {
+	c.NotifyWithContext(ContextWithCorrelationId(context.Background(), correlationId), components, args)
+}
+
+// Notifies multiple components using the context.
+// Notifications stop when the context is cancelled.
+// see
+// NotifyOneWithContext
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  a list of components that are to be notified.
+//  - args *Parameters
+//  notification arguments.
+func (c *TNotifier) NotifyWithContext(ctx context.Context, components []interface{}, args *Parameters) {
 	for _, component := range components {
-		c.NotifyOne(correlationId, component, args)
+		c.NotifyOneWithContext(ctx, component, args)
 	}
 }
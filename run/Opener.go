@@ -1,5 +1,7 @@
 package run
 
+import "context"
+
 /*
 Helper class that opens components.
 */
@@ -16,8 +18,10 @@ var Opener *TOpener = &TOpener{}
 // Returns bool
 // true if component is opened and false otherwise.
 func (c *TOpener) IsOpenOne(component interface{}) bool {
-	v, ok := component.(IOpenable)
-	if ok {
+	if v, ok := component.(IContextOpenable); ok {
+		return v.IsOpen()
+	}
+	if v, ok := component.(IOpenable); ok {
 		return v.IsOpen()
 	}
 	return true
@@ -57,9 +61,31 @@ func (c *TOpener) IsOpen(components []interface{}) bool {
 // 			the component that is to be opened.
 // Returns error
 func (c *TOpener) OpenOne(correlationId string, component interface{}) error {
-	v, ok := component.(IOpenable)
-	if ok {
-		return v.Open(correlationId)
+	return c.OpenOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component)
+}
+
+// Opens specific component using the context.
+// Components that implement IContextOpenable interface receive the context,
+// components that implement IOpenable interface receive the correlation id carried by the context.
+// see
+// IContextOpenable
+// see
+// IOpenable
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be opened.
+// Returns error
+func (c *TOpener) OpenOneWithContext(ctx context.Context, component interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if v, ok := component.(IContextOpenable); ok {
+		return v.Open(ctx)
+	}
+	if v, ok := component.(IOpenable); ok {
+		return v.Open(GetCorrelationId(ctx))
 	}
 	return nil
 }
@@ -77,11 +103,42 @@ func (c *TOpener) OpenOne(correlationId string, component interface{}) error {
 // 			the list of components that are to be closed.
 // Returns error
 func (c *TOpener) Open(correlationId string, components []interface{}) error {
+	return c.OpenWithContext(ContextWithCorrelationId(context.Background(), correlationId), components)
+}
+
+// Opens multiple components using the context.
+// Opening stops when the context is cancelled.
+// see
+// OpenOneWithContext
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  the list of components that are to be opened.
+// Returns error
+func (c *TOpener) OpenWithContext(ctx context.Context, components []interface{}) error {
 	for _, component := range components {
-		err := c.OpenOne(correlationId, component)
+		err := c.OpenOneWithContext(ctx, component)
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// Opens multiple independent components concurrently.
+// Unlike Open, it doesn't stop at the first error and doesn't close opened components on failure.
+// All failures are returned as one InvocationError with descriptions of all errors in "errors" details.
+// see
+// LifecycleOptions
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - components []interface{}
+//  the list of components that are to be opened.
+//  - options *LifecycleOptions
+//  the parallelism limit and per-component timeout. If it is nil, all components are opened at once without timeout.
+// Returns error
+func (c *TOpener) OpenParallel(ctx context.Context, components []interface{}, options *LifecycleOptions) error {
+	return runParallel(ctx, components, options, "open", c.OpenOneWithContext)
+}
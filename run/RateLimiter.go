@@ -0,0 +1,161 @@
+package run
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Token bucket rate limiter that protects components from overload.
+
+The bucket holds up to burst tokens and is refilled at the specified rate.
+Each call takes one token. When the bucket is empty, calls are rejected with InvalidStateError
+with code "RATE_LIMITED" and "retry_after" detail in milliseconds.
+
+Configuration parameters
+ rate_limit:
+   rate: number of calls allowed per second
+   burst: maximum number of calls allowed at once
+
+see
+Bulkhead
+
+Example:
+ limiter := NewRateLimiter(10, 20)
+ result, err := limiter.ExecuteOne("123", component, args)
+*/
+type RateLimiter struct {
+	rate    float64
+	burst   int
+	tokens  float64
+	updated time.Time
+	clock   IClock
+	lock    sync.Mutex
+}
+
+// Creates a new instance of the rate limiter.
+// Parameters:
+//  - rate float64
+//  number of calls allowed per second.
+//  - burst int
+//  maximum number of calls allowed at once.
+// Returns *RateLimiter
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	clock := NewRealClock()
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		tokens:  float64(burst),
+		updated: clock.Now(),
+		clock:   clock,
+	}
+}
+
+// Configures the limiter with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config *config.ConfigParams
+//  configuration parameters to set.
+func (c *RateLimiter) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.rate = config.GetAsDoubleWithDefault("rate_limit.rate", c.rate)
+	c.burst = config.GetAsIntegerWithDefault("rate_limit.burst", c.burst)
+	c.tokens = float64(c.burst)
+	c.updated = c.clock.Now()
+}
+
+// Sets the clock used to refill the bucket.
+// Parameters:
+//  - value IClock
+//  the clock to be used.
+func (c *RateLimiter) SetClock(value IClock) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clock = value
+	c.updated = value.Now()
+}
+
+// Takes a token from the bucket if it is available.
+// throws
+// InvalidStateError when the rate limit is exceeded.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns error
+func (c *RateLimiter) Acquire(correlationId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.clock.Now()
+	c.tokens = math.Min(float64(c.burst), c.tokens+now.Sub(c.updated).Seconds()*c.rate)
+	c.updated = now
+
+	if c.tokens >= 1 {
+		c.tokens--
+		return nil
+	}
+
+	retryAfter := int64(math.MaxInt32)
+	if c.rate > 0 {
+		retryAfter = int64(math.Ceil((1 - c.tokens) / c.rate * 1000))
+	}
+	return errors.NewInvalidStateError(
+		correlationId,
+		"RATE_LIMITED",
+		"Rate limit exceeded",
+	).WithDetails("rate", c.rate).WithDetails("retry_after", retryAfter)
+}
+
+// Calls the action when the rate limit allows it.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - action func() (interface{}, error)
+//  the function to call.
+// Returns interface{}, error
+// the action result or InvalidStateError when the rate limit is exceeded.
+func (c *RateLimiter) Call(correlationId string, action func() (interface{}, error)) (interface{}, error) {
+	if err := c.Acquire(correlationId); err != nil {
+		return nil, err
+	}
+	return action()
+}
+
+// Executes specific component when the rate limit allows it.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *RateLimiter) ExecuteOne(correlationId string, component interface{}, args *Parameters) (interface{}, error) {
+	return c.ExecuteOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Executes specific component using the context when the rate limit allows it.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or error.
+func (c *RateLimiter) ExecuteOneWithContext(ctx context.Context, component interface{}, args *Parameters) (interface{}, error) {
+	return c.Call(GetCorrelationId(ctx), func() (interface{}, error) {
+		return Executor.ExecuteOneWithContext(ctx, component, args)
+	})
+}
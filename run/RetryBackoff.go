@@ -0,0 +1,19 @@
+package run
+
+/*
+Defines how delays between retry attempts grow.
+
+ConstantBackoff - the same delay before each retry.
+
+ExponentialBackoff - the delay doubles after each attempt up to the maximum delay.
+
+DecorrelatedJitterBackoff - a random delay between the initial delay and three times the previous delay,
+limited by the maximum delay. It spreads retries from many clients over time.
+*/
+type RetryBackoff int
+
+const (
+	ConstantBackoff RetryBackoff = iota
+	ExponentialBackoff
+	DecorrelatedJitterBackoff
+)
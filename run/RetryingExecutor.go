@@ -0,0 +1,278 @@
+package run
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+)
+
+/*
+Executes components and retries failed executions with backoff.
+
+Only errors caused by communication problems are retried: ApplicationError with NoResponse
+or FailedInvocation category. Other errors, for instance BadRequest or Unauthorized, are returned immediately.
+Retries stop when the maximum number of attempts is reached, when the next delay exceeds the maximum elapsed time
+or when the context is cancelled. In all these cases the last error is returned.
+
+Configuration parameters
+ retries:
+   attempts: maximum number of attempts including the first one (default: 3)
+   timeout: maximum elapsed time for all attempts in milliseconds (default: 0 - no limit)
+   backoff: "constant", "exponential" or "decorrelated_jitter" (default: "exponential")
+   delay: initial delay between attempts in milliseconds (default: 100)
+   max_delay: maximum delay between attempts in milliseconds (default: 10000, 0 - no limit)
+
+see
+IExecutable
+
+see
+Executor
+
+see
+RetryBackoff
+
+Example:
+ executor := NewRetryingExecutor()
+ executor.Configure(config.NewConfigParamsFromTuples(
+ 	"retries.attempts", 5,
+ 	"retries.timeout", 30000,
+ ))
+
+ result, err := executor.ExecuteOne("123", component, NewParametersFromTuples("message", "ABC"))
+*/
+type RetryingExecutor struct {
+	attempts int
+	timeout  time.Duration
+	backoff  RetryBackoff
+	delay    time.Duration
+	maxDelay time.Duration
+	clock    IClock
+}
+
+// Creates a new instance of the retrying executor with default settings.
+// Returns *RetryingExecutor
+func NewRetryingExecutor() *RetryingExecutor {
+	return &RetryingExecutor{
+		attempts: 3,
+		backoff:  ExponentialBackoff,
+		delay:    100 * time.Millisecond,
+		maxDelay: 10 * time.Second,
+		clock:    NewRealClock(),
+	}
+}
+
+// Configures the executor with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config *config.ConfigParams
+//  configuration parameters to set.
+func (c *RetryingExecutor) Configure(config *config.ConfigParams) {
+	c.attempts = config.GetAsIntegerWithDefault("retries.attempts", c.attempts)
+	c.timeout = time.Duration(config.GetAsLongWithDefault("retries.timeout", c.timeout.Milliseconds())) * time.Millisecond
+	c.delay = time.Duration(config.GetAsLongWithDefault("retries.delay", c.delay.Milliseconds())) * time.Millisecond
+	c.maxDelay = time.Duration(config.GetAsLongWithDefault("retries.max_delay", c.maxDelay.Milliseconds())) * time.Millisecond
+
+	switch strings.ToLower(config.GetAsStringWithDefault("retries.backoff", "")) {
+	case "constant":
+		c.backoff = ConstantBackoff
+	case "exponential":
+		c.backoff = ExponentialBackoff
+	case "decorrelated_jitter", "jitter":
+		c.backoff = DecorrelatedJitterBackoff
+	}
+}
+
+// Gets the maximum number of attempts including the first one.
+// Returns int
+// the maximum number of attempts.
+func (c *RetryingExecutor) Attempts() int {
+	return c.attempts
+}
+
+// Sets the maximum number of attempts including the first one.
+// Parameters:
+//  - value int
+//  the maximum number of attempts.
+func (c *RetryingExecutor) SetAttempts(value int) {
+	c.attempts = value
+}
+
+// Gets the maximum elapsed time for all attempts.
+// Returns time.Duration
+// the maximum elapsed time or 0 if it is not limited.
+func (c *RetryingExecutor) Timeout() time.Duration {
+	return c.timeout
+}
+
+// Sets the maximum elapsed time for all attempts.
+// Parameters:
+//  - value time.Duration
+//  the maximum elapsed time or 0 for no limit.
+func (c *RetryingExecutor) SetTimeout(value time.Duration) {
+	c.timeout = value
+}
+
+// Gets the backoff policy.
+// Returns RetryBackoff
+// the backoff policy.
+func (c *RetryingExecutor) Backoff() RetryBackoff {
+	return c.backoff
+}
+
+// Sets the backoff policy and delays between attempts.
+// Parameters:
+//  - backoff RetryBackoff
+//  the backoff policy.
+//  - delay time.Duration
+//  the initial delay between attempts.
+//  - maxDelay time.Duration
+//  the maximum delay between attempts.
+func (c *RetryingExecutor) SetBackoff(backoff RetryBackoff, delay time.Duration, maxDelay time.Duration) {
+	c.backoff = backoff
+	c.delay = delay
+	c.maxDelay = maxDelay
+}
+
+// Sets the clock used to wait between attempts.
+// Parameters:
+//  - value IClock
+//  the clock to be used.
+func (c *RetryingExecutor) SetClock(value IClock) {
+	c.clock = value
+}
+
+// Checks if the error is transient and the execution can be retried.
+// Parameters:
+//  - err error
+//  the error to check.
+// Returns bool
+// true if the error has NoResponse or FailedInvocation category and false otherwise.
+func IsRetriableError(err error) bool {
+	appErr, ok := err.(*errors.ApplicationError)
+	if !ok || appErr == nil {
+		return false
+	}
+	return appErr.Category == errors.NoResponse || appErr.Category == errors.FailedInvocation
+}
+
+// Calculates the delay before the next attempt.
+// Delays are limited by the max delay or by the maximum duration when it is not set.
+func (c *RetryingExecutor) nextDelay(attempt int, previous time.Duration) time.Duration {
+	limit := c.maxDelay
+	if limit <= 0 {
+		limit = time.Duration(math.MaxInt64)
+	}
+
+	var delay time.Duration
+
+	switch c.backoff {
+	case ExponentialBackoff:
+		delay = c.delay
+		for index := 1; index < attempt && delay < limit; index++ {
+			if delay > limit/2 {
+				delay = limit
+				break
+			}
+			delay *= 2
+		}
+	case DecorrelatedJitterBackoff:
+		upper := limit
+		if previous < limit/3 {
+			upper = previous * 3
+		}
+		if upper <= c.delay {
+			delay = c.delay
+		} else {
+			delay = c.delay + time.Duration(rand.Int63n(int64(upper-c.delay)))
+		}
+	default:
+		delay = c.delay
+	}
+
+	if delay > limit {
+		delay = limit
+	}
+	return delay
+}
+
+// Executes specific component and retries it on transient errors.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or the last error.
+func (c *RetryingExecutor) ExecuteOne(correlationId string, component interface{}, args *Parameters) (interface{}, error) {
+	return c.ExecuteOneWithContext(ContextWithCorrelationId(context.Background(), correlationId), component, args)
+}
+
+// Executes specific component using the context and retries it on transient errors.
+// Waiting between attempts is interrupted when the context is cancelled.
+// Parameters:
+//  - ctx context.Context
+//  the context with cancellation, deadline and correlation id.
+//  - component interface{}
+//  the component that is to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns interface{}, error
+// execution result or the last error.
+func (c *RetryingExecutor) ExecuteOneWithContext(ctx context.Context, component interface{}, args *Parameters) (interface{}, error) {
+	clock := c.clock
+	if clock == nil {
+		clock = NewRealClock()
+	}
+	start := clock.Now()
+	delay := time.Duration(0)
+
+	for attempt := 1; ; attempt++ {
+		result, err := Executor.ExecuteOneWithContext(ctx, component, args)
+		if err == nil || !IsRetriableError(err) || attempt >= c.attempts {
+			return result, err
+		}
+
+		delay = c.nextDelay(attempt, delay)
+		if c.timeout > 0 && clock.Now().Sub(start)+delay > c.timeout {
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-clock.After(delay):
+		}
+	}
+}
+
+// Executes multiple components one by one and retries each of them on transient errors.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+//  - components []interface{}
+//  a list of components that are to be executed.
+//  - args *Parameters
+//  execution arguments.
+// Returns []interface{}, error
+// execution results and error.
+func (c *RetryingExecutor) Execute(correlationId string, components []interface{}, args *Parameters) ([]interface{}, error) {
+	results := make([]interface{}, 0, len(components))
+
+	for _, component := range components {
+		result, err := c.ExecuteOne(correlationId, component, args)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,319 @@
+package run
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+)
+
+/*
+Result of a graceful shutdown.
+
+reason - What triggered the shutdown: "signal: <name>" for OS signals or "requested" for programmatic calls
+closed - Names of components that were closed successfully
+failed - Error messages of components that failed to close by their names
+timed_out - Names of components that didn't close before the deadline
+duration - Duration of the shutdown in milliseconds
+*/
+type ShutdownReport struct {
+	Reason   string            `json:"reason"`
+	Closed   []string          `json:"closed"`
+	Failed   map[string]string `json:"failed"`
+	TimedOut []string          `json:"timed_out"`
+	Duration int64             `json:"duration"`
+}
+
+// Checks if all components were closed successfully.
+// Returns bool
+// true if no components failed or timed out and false otherwise.
+func (c *ShutdownReport) IsComplete() bool {
+	return len(c.Failed) == 0 && len(c.TimedOut) == 0
+}
+
+type shutdownEntry struct {
+	name      string
+	component interface{}
+	priority  int
+	sequence  int
+}
+
+/*
+Coordinates graceful shutdown of components.
+
+Components are registered with priorities. When the shutdown is triggered by an OS signal or programmatically,
+the coordinator waits for the drain period to let in-flight work complete, and then closes components
+in groups from the highest priority to the lowest. Components with the same priority are closed concurrently.
+All components must close before the shutdown deadline. Components that don't close in time are abandoned
+and reported in the ShutdownReport, so the process can exit anyway.
+
+Components shall implement IClosable or IContextClosable interface. Context-aware components
+receive a context that is cancelled at the deadline.
+
+Configuration parameters
+ shutdown:
+   timeout: deadline to close all components in milliseconds (default: 30000)
+   drain_period: delay before closing components in milliseconds (default: 0)
+
+see
+Closer
+
+see
+ShutdownReport
+
+Example:
+ coordinator := NewShutdownCoordinator()
+ coordinator.Register("http_endpoint", endpoint, 100)
+ coordinator.Register("persistence", persistence, 0)
+ coordinator.ListenSignals()
+
+ report := coordinator.Wait()
+ if !report.IsComplete() {
+ 	os.Exit(1)
+ }
+*/
+type ShutdownCoordinator struct {
+	timeout     time.Duration
+	drainPeriod time.Duration
+	entries     []*shutdownEntry
+	signals     chan os.Signal
+	started     bool
+	report      *ShutdownReport
+	done        chan struct{}
+	lock        sync.Mutex
+}
+
+// Creates a new instance of the shutdown coordinator with default settings.
+// Returns *ShutdownCoordinator
+func NewShutdownCoordinator() *ShutdownCoordinator {
+	return &ShutdownCoordinator{
+		timeout: 30 * time.Second,
+		entries: []*shutdownEntry{},
+		done:    make(chan struct{}),
+	}
+}
+
+// Configures the coordinator with specified parameters.
+// see
+// ConfigParams
+// Parameters:
+//  - config *config.ConfigParams
+//  configuration parameters to set.
+func (c *ShutdownCoordinator) Configure(config *config.ConfigParams) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timeout = time.Duration(config.GetAsLongWithDefault("shutdown.timeout", c.timeout.Milliseconds())) * time.Millisecond
+	c.drainPeriod = time.Duration(config.GetAsLongWithDefault("shutdown.drain_period", c.drainPeriod.Milliseconds())) * time.Millisecond
+}
+
+// Sets the deadline to close all components.
+// Parameters:
+//  - value time.Duration
+//  the deadline measured from the moment the shutdown is triggered.
+func (c *ShutdownCoordinator) SetTimeout(value time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timeout = value
+}
+
+// Sets the delay before closing components that lets in-flight work complete.
+// Parameters:
+//  - value time.Duration
+//  the drain period.
+func (c *ShutdownCoordinator) SetDrainPeriod(value time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.drainPeriod = value
+}
+
+// Registers a component to be closed during the shutdown.
+// Components registered after the shutdown was triggered are not closed.
+// Parameters:
+//  - name string
+//  the component name in the report.
+//  - component interface{}
+//  the component that implements IClosable or IContextClosable interface.
+//  - priority int
+//  the component priority. Components with higher priorities are closed first.
+func (c *ShutdownCoordinator) Register(name string, component interface{}, priority int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = append(c.entries, &shutdownEntry{
+		name:      name,
+		component: component,
+		priority:  priority,
+		sequence:  len(c.entries),
+	})
+}
+
+// Starts listening to OS signals that trigger the shutdown.
+// Parameters:
+//  - signals ...os.Signal
+//  the signals to listen to. If none are set, SIGINT and SIGTERM are used.
+func (c *ShutdownCoordinator) ListenSignals(signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.signals != nil {
+		signal.Stop(c.signals)
+	}
+	channel := make(chan os.Signal, 1)
+	c.signals = channel
+	signal.Notify(channel, signals...)
+
+	go func() {
+		select {
+		case received := <-channel:
+			c.shutdown("shutdown", "signal: "+received.String())
+		case <-c.done:
+		}
+	}()
+}
+
+// Stops listening to OS signals.
+func (c *ShutdownCoordinator) StopSignals() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.signals != nil {
+		signal.Stop(c.signals)
+		c.signals = nil
+	}
+}
+
+// Checks if the shutdown was triggered.
+// Returns bool
+// true if the shutdown was triggered and false otherwise.
+func (c *ShutdownCoordinator) IsShuttingDown() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.started
+}
+
+// Gets a channel that is closed when the shutdown completes.
+// Returns <-chan struct{}
+// the channel to wait on.
+func (c *ShutdownCoordinator) Done() <-chan struct{} {
+	return c.done
+}
+
+// Waits until the shutdown completes.
+// Returns *ShutdownReport
+// the shutdown report.
+func (c *ShutdownCoordinator) Wait() *ShutdownReport {
+	<-c.done
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.report
+}
+
+// Triggers the shutdown and waits until it completes.
+// When the shutdown was already triggered, it just waits for its completion.
+// Parameters:
+//  - correlationId string
+//  transaction id to trace execution through call chain.
+// Returns *ShutdownReport
+// the shutdown report.
+func (c *ShutdownCoordinator) Shutdown(correlationId string) *ShutdownReport {
+	return c.shutdown(correlationId, "requested")
+}
+
+func (c *ShutdownCoordinator) shutdown(correlationId string, reason string) *ShutdownReport {
+	c.lock.Lock()
+	if c.started {
+		c.lock.Unlock()
+		return c.Wait()
+	}
+	c.started = true
+	entries := append([]*shutdownEntry{}, c.entries...)
+	timeout := c.timeout
+	drainPeriod := c.drainPeriod
+	c.lock.Unlock()
+
+	c.StopSignals()
+
+	start := time.Now()
+	report := &ShutdownReport{
+		Reason:   reason,
+		Closed:   []string{},
+		Failed:   map[string]string{},
+		TimedOut: []string{},
+	}
+
+	ctx := ContextWithCorrelationId(context.Background(), correlationId)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if drainPeriod > 0 {
+		select {
+		case <-time.After(drainPeriod):
+		case <-ctx.Done():
+		}
+	}
+
+	// Close groups of components from the highest priority to the lowest
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].priority > entries[j].priority
+	})
+	for index := 0; index < len(entries); {
+		end := index
+		for end < len(entries) && entries[end].priority == entries[index].priority {
+			end++
+		}
+		c.closeGroup(ctx, entries[index:end], report)
+		index = end
+	}
+
+	report.Duration = time.Since(start).Milliseconds()
+
+	c.lock.Lock()
+	c.report = report
+	c.lock.Unlock()
+	close(c.done)
+
+	return report
+}
+
+func (c *ShutdownCoordinator) closeGroup(ctx context.Context, entries []*shutdownEntry, report *ShutdownReport) {
+	failures := make([]error, len(entries))
+
+	var wait sync.WaitGroup
+	for index, entry := range entries {
+		wait.Add(1)
+		go func(index int, entry *shutdownEntry) {
+			defer wait.Done()
+			failures[index] = callWithTimeout(ctx, 0, "close", entry.component, Closer.CloseOneWithContext)
+		}(index, entry)
+	}
+	wait.Wait()
+
+	for index, entry := range entries {
+		switch err := failures[index]; {
+		case err == nil:
+			report.Closed = append(report.Closed, entry.name)
+		case err == context.DeadlineExceeded || err == context.Canceled:
+			report.TimedOut = append(report.TimedOut, entry.name)
+		default:
+			report.Failed[entry.name] = err.Error()
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package run
+
+/*
+Defines what happens when a timer is triggered while the previous callback is still running.
+
+OverlapSkip - the triggering is skipped.
+
+OverlapQueue - the callback is called again right after the running one completes, once for each skipped triggering.
+
+OverlapConcurrent - the callback is called concurrently with the running one.
+*/
+type TimerOverlapPolicy int
+
+const (
+	OverlapSkip TimerOverlapPolicy = iota
+	OverlapQueue
+	OverlapConcurrent
+)
@@ -0,0 +1,35 @@
+package run
+
+import "time"
+
+/*
+Statistics of callbacks called by a timer. It is used to monitor periodic processing.
+
+Triggered - number of times the timer was triggered
+Completed - number of completed callbacks including failed ones
+Failed - number of callbacks that failed with panics
+Skipped - number of triggerings skipped because the previous callback was still running
+Queued - number of callbacks waiting for the running callback to complete
+Running - number of callbacks running at the moment
+LastStart - start time of the last completed callback
+LastDuration - duration of the last completed callback
+MaxDuration - maximum callback duration
+TotalDuration - total duration of all completed callbacks
+LastError - the last callback failure
+
+see
+FixedRateTimer
+*/
+type TimerStats struct {
+	Triggered     int64
+	Completed     int64
+	Failed        int64
+	Skipped       int64
+	Queued        int64
+	Running       int64
+	LastStart     time.Time
+	LastDuration  time.Duration
+	MaxDuration   time.Duration
+	TotalDuration time.Duration
+	LastError     error
+}
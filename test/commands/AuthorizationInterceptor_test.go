@@ -0,0 +1,103 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuthorizedCommandSet() (*commands.CommandSet, *[]*run.Parameters) {
+	calls := []*run.Parameters{}
+	action := func(correlationId string, args *run.Parameters) (interface{}, error) {
+		calls = append(calls, args)
+		return "OK", nil
+	}
+
+	interceptor := commands.NewAuthorizationInterceptor()
+	interceptor.Configure(config.NewConfigParamsFromTuples(
+		"permissions.get_dummies", "user, admin",
+		"permissions.delete_dummy", "admin",
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(commands.NewCommand("get_dummies", nil, action))
+	commandSet.AddCommand(commands.NewCommand("delete_dummy",
+		validate.NewObjectSchema().WithRequiredProperty("dummy_id", convert.String),
+		action,
+	))
+	commandSet.AddCommand(commands.NewCommand("ping", nil, action))
+	return commandSet, &calls
+}
+
+func TestAuthorizationInterceptorAllowsPermittedCalls(t *testing.T) {
+	commandSet, calls := newAuthorizedCommandSet()
+
+	user := commands.NewPrincipal("user1", "user")
+	admin := commands.NewPrincipal("admin1", "admin")
+
+	result, err := commandSet.Execute("123", "get_dummies", commands.WithPrincipal(nil, user))
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+
+	// Strict schema validation doesn't see the principal
+	args := commands.WithPrincipal(run.NewParametersFromTuples("dummy_id", "1"), admin)
+	_, err = commandSet.Execute("123", "delete_dummy", args)
+	assert.Nil(t, err)
+	assert.False(t, (*calls)[1].Contains(commands.PrincipalParameter))
+	assert.Equal(t, admin, commands.GetPrincipal(args))
+
+	// Unrestricted commands are available to anonymous callers
+	_, err = commandSet.Execute("123", "ping", run.NewEmptyParameters())
+	assert.Nil(t, err)
+
+	// Permissions can be granted directly
+	operator := commands.NewPrincipal("operator1")
+	operator.Permissions = []string{"admin"}
+	_, err = commandSet.Execute("123", "delete_dummy",
+		commands.WithPrincipal(run.NewParametersFromTuples("dummy_id", "1"), operator))
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(*calls))
+}
+
+func TestAuthorizationInterceptorRejectsCalls(t *testing.T) {
+	commandSet, calls := newAuthorizedCommandSet()
+
+	_, err := commandSet.Execute("123", "get_dummies", run.NewEmptyParameters())
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "NOT_AUTHENTICATED", appErr.Code)
+	assert.Equal(t, errors.Unauthorized, appErr.Category)
+
+	args := commands.WithPrincipal(run.NewParametersFromTuples("dummy_id", "1"), commands.NewPrincipal("user1", "user"))
+	_, err = commandSet.Execute("123", "delete_dummy", args)
+	appErr = err.(*errors.ApplicationError)
+	assert.Equal(t, "ACCESS_DENIED", appErr.Code)
+	assert.Equal(t, errors.Unauthorized, appErr.Category)
+	assert.Equal(t, "delete_dummy", appErr.Details["command"])
+	assert.Equal(t, "user1", appErr.Details["principal"])
+	assert.Equal(t, []string{"admin"}, appErr.Details["missing_permissions"])
+
+	assert.Equal(t, 0, len(*calls))
+}
+
+func TestAuthorizationInterceptorDefaultPermissions(t *testing.T) {
+	interceptor := commands.NewAuthorizationInterceptor()
+	interceptor.Configure(config.NewConfigParamsFromTuples(
+		"permissions.ping", "",
+		"default_permissions", "admin",
+	))
+	interceptor.SetPermissions("get_dummies", "user")
+
+	assert.Equal(t, []string{}, interceptor.Permissions("ping"))
+	assert.Equal(t, []string{"user"}, interceptor.Permissions("get_dummies"))
+	assert.Equal(t, []string{"admin"}, interceptor.Permissions("delete_dummy"))
+
+	assert.Nil(t, interceptor.Authorize("123", "ping", nil))
+	assert.NotNil(t, interceptor.Authorize("123", "delete_dummy", commands.NewPrincipal("user1", "user")))
+}
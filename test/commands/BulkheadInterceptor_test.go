@@ -0,0 +1,31 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkheadInterceptor(t *testing.T) {
+	bulkhead := run.NewBulkhead(1, 0, 0)
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewBulkheadInterceptor(bulkhead))
+
+	var nested error
+	command := commands.NewCommand("call", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		// Nested call is rejected while the only slot is busy
+		_, nested = commandSet.Execute(correlationId, "call", args)
+		return "OK", nil
+	})
+	commandSet.AddCommand(command)
+
+	result, err := commandSet.Execute("123", "call", run.NewEmptyParameters())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	assert.NotNil(t, nested)
+	assert.Equal(t, "BULKHEAD_FULL", nested.(*errors.ApplicationError).Code)
+	assert.Equal(t, 0, bulkhead.Running())
+}
@@ -0,0 +1,49 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type circuitListener struct {
+	states []string
+}
+
+func (c *circuitListener) OnEvent(correlationId string, e commands.IEvent, value *run.Parameters) {
+	c.states = append(c.states, value.GetAsString("to"))
+}
+
+func TestCircuitBreakerInterceptor(t *testing.T) {
+	breaker := run.NewCircuitBreaker("test")
+	breaker.Configure(config.NewConfigParamsFromTuples(
+		"circuit.min_requests", 2,
+		"circuit.failure_rate", 1,
+	))
+	event := commands.NewEvent("circuit_changed")
+	listener := &circuitListener{}
+	event.AddListener(listener)
+	breaker.SetEvent(event)
+
+	calls := 0
+	command := commands.NewCommand("call", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		calls++
+		return nil, errors.NewInvocationError(correlationId, "FAILED", "Call failed")
+	})
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewCircuitBreakerInterceptor(breaker))
+	commandSet.AddCommand(command)
+
+	commandSet.Execute("123", "call", run.NewEmptyParameters())
+	commandSet.Execute("123", "call", run.NewEmptyParameters())
+	_, err := commandSet.Execute("123", "call", run.NewEmptyParameters())
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "CIRCUIT_OPEN", err.(*errors.ApplicationError).Code)
+	assert.Equal(t, []string{run.CircuitOpen}, listener.states)
+}
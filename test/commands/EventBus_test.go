@@ -0,0 +1,115 @@
+package test_commands
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type busListener struct {
+	events []string
+}
+
+func (c *busListener) OnEvent(correlationId string, e commands.IEvent, value *run.Parameters) {
+	c.events = append(c.events, e.Name())
+}
+
+func TestMatchEventName(t *testing.T) {
+	assert.True(t, commands.MatchEventName("orders.created", "orders.created"))
+	assert.False(t, commands.MatchEventName("orders.created", "orders.deleted"))
+
+	assert.True(t, commands.MatchEventName("orders.*", "orders.created"))
+	assert.False(t, commands.MatchEventName("orders.*", "orders"))
+	assert.False(t, commands.MatchEventName("orders.*", "orders.items.added"))
+	assert.True(t, commands.MatchEventName("*.created", "orders.created"))
+
+	assert.True(t, commands.MatchEventName("orders.**", "orders.items.added"))
+	assert.True(t, commands.MatchEventName("orders.**", "orders"))
+	assert.True(t, commands.MatchEventName("**.added", "orders.items.added"))
+	assert.True(t, commands.MatchEventName("**", "orders.created"))
+}
+
+func TestEventBusPublishAndUnsubscribe(t *testing.T) {
+	bus := commands.NewEventBus()
+
+	orders := &busListener{}
+	all := &busListener{}
+	subscription := bus.Subscribe("orders.*", orders)
+	bus.Subscribe("**", all)
+
+	bus.Publish("123", "orders.created", nil)
+	bus.Publish("123", "customers.created", nil)
+
+	assert.Equal(t, []string{"orders.created"}, orders.events)
+	assert.Equal(t, []string{"orders.created", "customers.created"}, all.events)
+
+	// Subscription applies to events published before it was made
+	late := &busListener{}
+	bus.Subscribe("*.created", late)
+	bus.Publish("123", "customers.created", nil)
+	assert.Equal(t, []string{"customers.created"}, late.events)
+
+	subscription.Unsubscribe()
+	subscription.Unsubscribe()
+	bus.Publish("123", "orders.created", nil)
+	assert.Equal(t, []string{"orders.created"}, orders.events)
+	assert.Equal(t, 4, len(all.events))
+}
+
+func TestEventBusPublishDistinctNames(t *testing.T) {
+	bus := commands.NewEventBus()
+	bus.SetDeliveryMode(commands.DeliveryQueued, 100)
+
+	listener := &busListener{}
+	bus.Subscribe("orders.*.created", listener)
+
+	expected := []string{}
+	for i := 0; i < 50; i++ {
+		name := "orders." + strconv.Itoa(i) + ".created"
+		expected = append(expected, name)
+		bus.Publish("123", name, nil)
+		bus.Publish("123", "customers."+strconv.Itoa(i)+".created", nil)
+	}
+
+	bus.Close("123")
+	assert.Equal(t, expected, listener.events)
+}
+
+func TestEventBusFilter(t *testing.T) {
+	bus := commands.NewEventBus()
+
+	listener := &busListener{}
+	subscription := bus.SubscribeWithFilter("orders.*", listener,
+		func(correlationId string, event commands.IEvent, args *run.Parameters) bool {
+			return args.GetAsString("region") == "eu"
+		},
+	)
+	assert.Equal(t, "orders.*", subscription.Pattern())
+
+	bus.Publish("123", "orders.created", run.NewParametersFromTuples("region", "us"))
+	bus.Publish("123", "orders.deleted", run.NewParametersFromTuples("region", "eu"))
+
+	assert.Equal(t, []string{"orders.deleted"}, listener.events)
+}
+
+func TestEventBusInReferences(t *testing.T) {
+	bus := commands.NewEventBus()
+	bus.SetDeliveryMode(commands.DeliveryQueued, 10)
+	references := refer.NewReferencesFromTuples(commands.EventBusDescriptor, bus)
+
+	found, err := refer.GetOneRequired[*commands.EventBus](
+		references, refer.NewDescriptor("pip-services", "event-bus", "*", "*", "1.0"),
+	)
+	assert.Nil(t, err)
+
+	listener := &busListener{}
+	found.Subscribe("orders.*", listener)
+	bus.Publish("123", "orders.created", nil)
+
+	bus.Close("123")
+	assert.Equal(t, []string{"orders.created"}, listener.events)
+}
@@ -1,9 +1,12 @@
 package test_commands
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
 	"github.com/pip-services3-go/pip-services3-commons-go/run"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +38,164 @@ func TestEventNotify(t *testing.T) {
 	event.RemoveListener(listener)
 	assert.Equal(t, 0, len(event.Listeners()))
 }
+
+type recordingListener struct {
+	lock     sync.Mutex
+	received []string
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (c *recordingListener) OnEvent(correlationId string, e commands.IEvent, value *run.Parameters) {
+	if c.started != nil {
+		select {
+		case c.started <- struct{}{}:
+		default:
+		}
+	}
+	if c.release != nil {
+		<-c.release
+	}
+	if correlationId == "wrongId" {
+		panic("Test error")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.received = append(c.received, correlationId)
+}
+
+func (c *recordingListener) Received() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string{}, c.received...)
+}
+
+type failureRecorder struct {
+	lock  sync.Mutex
+	codes []string
+}
+
+func (c *failureRecorder) Handle(correlationId string, event commands.IEvent, listener commands.IEventListener, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.codes = append(c.codes, err.(*errors.ApplicationError).Code)
+}
+
+func (c *failureRecorder) Codes() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]string{}, c.codes...)
+}
+
+func TestEventRecoversListenerPanics(t *testing.T) {
+	event := commands.NewEvent("name")
+	failures := &failureRecorder{}
+	event.SetFailureHandler(failures.Handle)
+
+	listener1 := &recordingListener{}
+	listener2 := &recordingListener{}
+	event.AddListener(listener1)
+	event.AddListener(listener2)
+
+	event.Notify("wrongId", nil)
+	event.Notify("123", nil)
+
+	assert.Equal(t, []string{"EVENT_LISTENER_FAILED", "EVENT_LISTENER_FAILED"}, failures.Codes())
+	assert.Equal(t, []string{"123"}, listener1.Received())
+	assert.Equal(t, []string{"123"}, listener2.Received())
+}
+
+func TestEventAsyncDelivery(t *testing.T) {
+	event := commands.NewEvent("name")
+	event.SetDeliveryMode(commands.DeliveryAsync, 0)
+	failures := &failureRecorder{}
+	event.SetFailureHandler(failures.Handle)
+
+	listener := &recordingListener{release: make(chan struct{})}
+	event.AddListener(listener)
+
+	// Notify does not wait for the blocked listener
+	event.Notify("123", nil)
+	event.Notify("wrongId", nil)
+	assert.Equal(t, 0, len(listener.Received()))
+
+	close(listener.release)
+	event.Close("123")
+	assert.Equal(t, []string{"123"}, listener.Received())
+	assert.Equal(t, []string{"EVENT_LISTENER_FAILED"}, failures.Codes())
+
+	event.Notify("456", nil)
+	assert.Equal(t, []string{"EVENT_LISTENER_FAILED", "EVENT_CLOSED"}, failures.Codes())
+}
+
+func TestEventQueuedDelivery(t *testing.T) {
+	event := commands.NewEvent("name")
+	event.SetDeliveryMode(commands.DeliveryQueued, 2)
+	failures := &failureRecorder{}
+	event.SetFailureHandler(failures.Handle)
+
+	slow := &recordingListener{started: make(chan struct{}, 1), release: make(chan struct{})}
+	fast := &recordingListener{}
+	event.AddListener(slow)
+	event.AddListener(fast)
+
+	// The slow listener takes the first notification and blocks, two more fill its queue
+	event.Notify("1", nil)
+	<-slow.started
+	for i, id := range []string{"2", "3", "4"} {
+		event.Notify(id, nil)
+		// The fast listener is not blocked by the slow one
+		for len(fast.Received()) < i+2 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	assert.Equal(t, []string{"EVENT_QUEUE_FULL"}, failures.Codes())
+
+	close(slow.release)
+	event.Close("123")
+	assert.Equal(t, []string{"1", "2", "3"}, slow.Received())
+	assert.Equal(t, []string{"1", "2", "3", "4"}, fast.Received())
+}
+
+func TestEventChangeListenersDuringNotify(t *testing.T) {
+	event := commands.NewEvent("name")
+	event.SetDeliveryMode(commands.DeliveryQueued, 100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				listener := &recordingListener{}
+				event.AddListener(listener)
+				event.Notify("123", nil)
+				event.RemoveListener(listener)
+			}
+		}()
+	}
+	wg.Wait()
+
+	event.Close("123")
+	assert.Equal(t, 0, len(event.Listeners()))
+}
+
+func TestCommandSetDeliveryMode(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	event := commands.NewEvent("event")
+	commandSet.AddEvent(event)
+	commandSet.SetDeliveryMode(commands.DeliveryAsync, 0)
+	failures := &failureRecorder{}
+	commandSet.SetFailureHandler(failures.Handle)
+
+	listener := &recordingListener{}
+	commandSet.AddListener(listener)
+	commandSet.Notify("wrongId", "event", nil)
+	commandSet.Notify("123", "event", nil)
+
+	event.Close("123")
+	assert.Equal(t, commands.DeliveryAsync, event.DeliveryMode())
+	assert.Equal(t, []string{"123"}, listener.Received())
+	assert.Equal(t, []string{"EVENT_LISTENER_FAILED"}, failures.Codes())
+}
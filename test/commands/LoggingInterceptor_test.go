@@ -0,0 +1,126 @@
+package test_commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggingInterceptorRedactsArgs(t *testing.T) {
+	entries := []*commands.CommandLogEntry{}
+	interceptor := commands.NewLoggingInterceptor(commands.CommandLogSinkFunc(func(entry *commands.CommandLogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(commands.NewCommand("login", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return nil, errors.NewUnauthorizedError(correlationId, "WRONG_PASSWORD", "Wrong password")
+	}))
+
+	args := run.NewParametersFromTuples(
+		"login", "user1",
+		"Password", "pass123",
+		"connection", map[string]interface{}{
+			"host":      "localhost",
+			"api_token": "abc",
+		},
+		"keys", []interface{}{map[string]interface{}{"secret_key": "xyz"}},
+	)
+	_, err := commandSet.Execute("123", "login", args)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, 1, len(entries))
+	entry := entries[0]
+	assert.Equal(t, "123", entry.CorrelationId)
+	assert.Equal(t, "login", entry.Command)
+	assert.Equal(t, err, entry.Error)
+	assert.Equal(t, map[string]interface{}{
+		"login":    "user1",
+		"Password": commands.RedactedValue,
+		"connection": map[string]interface{}{
+			"host":      "localhost",
+			"api_token": commands.RedactedValue,
+		},
+		"keys": []interface{}{map[string]interface{}{"secret_key": commands.RedactedValue}},
+	}, entry.Args)
+
+	// Original arguments are not changed
+	assert.Equal(t, "pass123", args.GetAsString("Password"))
+
+	interceptor.SetRedactedKeys("LOGIN")
+	commandSet.Execute("123", "login", args)
+	assert.Equal(t, commands.RedactedValue, entries[1].Args["login"])
+	assert.Equal(t, "pass123", entries[1].Args["Password"])
+}
+
+func TestWriterCommandLogSink(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewLoggingInterceptor(commands.NewWriterCommandLogSink(buffer)))
+	commandSet.AddCommand(commands.NewCommand("call", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return nil, errors.NewInvocationError(correlationId, "FAILED", "Call failed")
+	}))
+
+	commandSet.Execute("123", "call", run.NewParametersFromTuples("token", "abc"))
+
+	line := buffer.String()
+	assert.True(t, strings.HasSuffix(line, "\n"))
+	assert.Contains(t, line, `[123] call args={"token":"***"} duration=`)
+	assert.Contains(t, line, "error=Call failed")
+}
+
+type loggedCredentials struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+	ApiKey   string
+	secret   string
+}
+
+type loggedToken struct {
+	Value string
+}
+
+func (c loggedToken) MarshalJSON() ([]byte, error) {
+	return []byte(`{"token":"` + c.Value + `"}`), nil
+}
+
+func TestLoggingInterceptorRedactsTypedValues(t *testing.T) {
+	entries := []*commands.CommandLogEntry{}
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewLoggingInterceptor(commands.CommandLogSinkFunc(func(entry *commands.CommandLogEntry) {
+		entries = append(entries, entry)
+	})))
+	commandSet.AddCommand(commands.NewCommand("call", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return nil, nil
+	}))
+
+	commandSet.Execute("123", "call", run.NewParametersFromTuples(
+		"headers", map[string]string{"api_key": "leak1", "accept": "json"},
+		"creds", &loggedCredentials{Login: "a", Password: "leak2", ApiKey: "leak3", secret: "leak4"},
+		"nested", run.NewParametersFromTuples("name", "b", "access_key", "leak5"),
+		"ids", map[int]string{1: "one"},
+		"token", loggedToken{Value: "leak6"},
+		"wrapped", loggedToken{Value: "leak7"},
+	))
+
+	assert.Equal(t, map[string]interface{}{
+		"headers": map[string]interface{}{"api_key": commands.RedactedValue, "accept": "json"},
+		"creds": map[string]interface{}{
+			"login": "a", "password": commands.RedactedValue, "ApiKey": commands.RedactedValue,
+		},
+		"nested":  map[string]interface{}{"name": "b", "access_key": commands.RedactedValue},
+		"ids":     map[string]interface{}{"1": "one"},
+		"token":   commands.RedactedValue,
+		"wrapped": map[string]interface{}{"token": commands.RedactedValue},
+	}, entries[0].Args)
+
+	json, _ := convert.ToJson(entries[0].Args)
+	assert.NotContains(t, json, "leak")
+}
@@ -0,0 +1,48 @@
+package test_commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsInterceptor(t *testing.T) {
+	registry := commands.NewCommandMetricsRegistry()
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewMetricsInterceptor(registry))
+
+	commandSet.AddCommand(commands.NewCommand("slow", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return nil, nil
+	}))
+	commandSet.AddCommand(commands.NewCommand("failing", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return nil, errors.NewInvocationError(correlationId, "FAILED", "Call failed")
+	}))
+
+	commandSet.Execute("123", "slow", run.NewEmptyParameters())
+	commandSet.Execute("123", "slow", run.NewEmptyParameters())
+	commandSet.Execute("123", "failing", run.NewEmptyParameters())
+
+	stats := registry.Get("slow")
+	assert.Equal(t, int64(2), stats.Calls)
+	assert.Equal(t, int64(0), stats.Errors)
+	assert.True(t, stats.MaxDuration >= 10*time.Millisecond)
+	assert.True(t, stats.AverageDuration() >= 10*time.Millisecond)
+	assert.Equal(t, stats.TotalDuration/2, stats.AverageDuration())
+
+	stats = registry.Get("failing")
+	assert.Equal(t, int64(1), stats.Calls)
+	assert.Equal(t, int64(1), stats.Errors)
+
+	all := registry.GetAll()
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, "failing", all[0].Name)
+	assert.Equal(t, "slow", all[1].Name)
+
+	registry.Reset()
+	assert.Nil(t, registry.Get("slow"))
+}
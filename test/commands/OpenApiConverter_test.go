@@ -0,0 +1,66 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDummyCommandSet() *commands.CommandSet {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCommand(
+		"get_dummy_by_id",
+		validate.NewObjectSchema().WithRequiredProperty("dummy_id", convert.String),
+		func(correlationId string, args *run.Parameters) (interface{}, error) {
+			return nil, nil
+		},
+	))
+	commandSet.AddCommand(commands.NewCommand(
+		"get_dummies",
+		nil,
+		func(correlationId string, args *run.Parameters) (interface{}, error) {
+			return nil, nil
+		},
+	))
+	return commandSet
+}
+
+func TestCommandSetToJsonSchemas(t *testing.T) {
+	schemas := commands.OpenApiConverter.ToJsonSchemas(newDummyCommandSet())
+
+	assert.Equal(t, 2, len(schemas))
+	schema := schemas["get_dummy_by_id"].(map[string]interface{})
+	assert.Equal(t, validate.JsonSchemaDialect, schema["$schema"])
+	assert.Equal(t, []string{"dummy_id"}, schema["required"])
+	assert.Equal(t, "object", schemas["get_dummies"].(map[string]interface{})["type"])
+}
+
+func TestCommandSetToOpenApi(t *testing.T) {
+	document := commands.OpenApiConverter.ToOpenApi(newDummyCommandSet(), "Dummies", "1.0")
+
+	assert.Equal(t, commands.OpenApiVersion, document["openapi"])
+	assert.Equal(t, map[string]interface{}{"title": "Dummies", "version": "1.0"}, document["info"])
+
+	paths := document["paths"].(map[string]interface{})
+	assert.Equal(t, 2, len(paths))
+
+	operation := paths["/get_dummy_by_id"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "get_dummy_by_id", operation["operationId"])
+
+	body := operation["requestBody"].(map[string]interface{})
+	schema := body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"dummy_id": map[string]interface{}{"type": "string"}}, schema["properties"])
+	assert.Nil(t, schema["$schema"])
+
+	responses := operation["responses"].(map[string]interface{})
+	assert.Contains(t, responses, "200")
+	assert.Contains(t, responses, "default")
+
+	json, err := commands.OpenApiConverter.ToJson(newDummyCommandSet(), "Dummies", "1.0")
+	assert.Nil(t, err)
+	assert.Contains(t, json, `"#/components/schemas/ErrorDescription"`)
+}
@@ -0,0 +1,29 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterInterceptor(t *testing.T) {
+	calls := 0
+	command := commands.NewCommand("call", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		calls++
+		return calls, nil
+	})
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewRateLimiterInterceptor(run.NewRateLimiter(0.001, 2)))
+	commandSet.AddCommand(command)
+
+	commandSet.Execute("123", "call", run.NewEmptyParameters())
+	commandSet.Execute("123", "call", run.NewEmptyParameters())
+	_, err := commandSet.Execute("123", "call", run.NewEmptyParameters())
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "RATE_LIMITED", err.(*errors.ApplicationError).Code)
+}
@@ -0,0 +1,42 @@
+package test_commands
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type panicCommand struct {
+	name string
+}
+
+func (c *panicCommand) Name() string {
+	return c.name
+}
+
+func (c *panicCommand) Execute(correlationId string, args *run.Parameters) (interface{}, error) {
+	panic("Test error")
+}
+
+func (c *panicCommand) Validate(args *run.Parameters) []*validate.ValidationResult {
+	return nil
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewRecoveryInterceptor())
+	commandSet.AddCommand(&panicCommand{name: "panic"})
+
+	result, err := commandSet.Execute("123", "panic", run.NewEmptyParameters())
+	assert.Nil(t, result)
+
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "EXEC_FAILED", appErr.Code)
+	assert.Equal(t, errors.FailedInvocation, appErr.Category)
+	assert.Equal(t, "panic", appErr.Details["command"])
+	assert.Contains(t, appErr.Message, "Test error")
+}
@@ -0,0 +1,62 @@
+package test_commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/commands"
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor := commands.NewTimeoutInterceptor(time.Second)
+	interceptor.Configure(config.NewConfigParamsFromTuples(
+		"timeouts.slow", 20,
+		"timeouts.unlimited", 0,
+	))
+	assert.Equal(t, 20*time.Millisecond, interceptor.Timeout("slow"))
+	assert.Equal(t, time.Second, interceptor.Timeout("fast"))
+
+	release := make(chan struct{})
+	defer close(release)
+	blocking := func(correlationId string, args *run.Parameters) (interface{}, error) {
+		<-release
+		return "OK", nil
+	}
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(commands.NewCommand("slow", nil, blocking))
+	commandSet.AddCommand(commands.NewCommand("fast", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return "OK", nil
+	}))
+	commandSet.AddCommand(commands.NewCommand("failing", nil, func(correlationId string, args *run.Parameters) (interface{}, error) {
+		return nil, errors.NewBadRequestError(correlationId, "BAD", "Bad request")
+	}))
+
+	result, err := commandSet.Execute("123", "fast", run.NewEmptyParameters())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+
+	_, err = commandSet.Execute("123", "failing", run.NewEmptyParameters())
+	assert.Equal(t, "BAD", err.(*errors.ApplicationError).Code)
+
+	_, err = commandSet.Execute("123", "slow", run.NewEmptyParameters())
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "COMMAND_TIMEOUT", appErr.Code)
+	assert.Equal(t, errors.NoResponse, appErr.Category)
+	assert.Equal(t, "slow", appErr.Details["command"])
+	assert.Equal(t, int64(20), appErr.Details["timeout"])
+}
+
+func TestTimeoutInterceptorRecoversPanics(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewTimeoutInterceptor(time.Second))
+	commandSet.AddCommand(&panicCommand{name: "panic"})
+
+	_, err := commandSet.Execute("123", "panic", run.NewEmptyParameters())
+	assert.Equal(t, "EXEC_FAILED", err.(*errors.ApplicationError).Code)
+}
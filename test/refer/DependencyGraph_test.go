@@ -0,0 +1,127 @@
+package test_refer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	log := []string{}
+	logger1 := newLifecycleComponent("logger1", &log)
+	logger2 := newLifecycleComponent("logger2", &log)
+	persistence := newLifecycleComponent("persistence", &log,
+		"logger", refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0"),
+	)
+	controller := newLifecycleComponent("controller", &log,
+		"persistence", refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"),
+		"cache", refer.NewDescriptor("pip-services", "cache", "*", "*", "1.0"),
+	)
+
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger1,
+		refer.NewDescriptor("pip-services", "logger", "file", "default", "1.0"), logger2,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), persistence,
+		refer.NewDescriptor("mygroup", "controller", "default", "default", "1.0"), controller,
+	)
+
+	graph := refer.BuildDependencyGraph(references)
+	assert.Len(t, graph.Nodes, 4)
+	assert.Equal(t, "c0", graph.Nodes[0].Id)
+	assert.Equal(t, "pip-services:logger:console:default:1.0", graph.Nodes[0].Locator)
+	assert.Equal(t, "*test_refer.lifecycleComponent", graph.Nodes[0].Type)
+
+	assert.Len(t, graph.Edges, 3)
+	assert.Equal(t, "c2", graph.Edges[0].From)
+	assert.Equal(t, "logger", graph.Edges[0].Name)
+	assert.Equal(t, []string{"c1", "c0"}, graph.Edges[0].To)
+	assert.Equal(t, refer.DependencyAmbiguous, graph.Edges[0].Status)
+
+	assert.Equal(t, "cache", graph.Edges[1].Name)
+	assert.Equal(t, refer.DependencyUnresolved, graph.Edges[1].Status)
+	assert.Equal(t, "persistence", graph.Edges[2].Name)
+	assert.Equal(t, []string{"c2"}, graph.Edges[2].To)
+	assert.Equal(t, refer.DependencyResolved, graph.Edges[2].Status)
+
+	unresolved := graph.GetUnresolved()
+	assert.Len(t, unresolved, 1)
+	assert.Equal(t, "pip-services:cache:*:*:1.0", unresolved[0].Locator)
+	assert.Len(t, graph.GetAmbiguous(), 1)
+}
+
+func TestBuildDependencyGraphForScope(t *testing.T) {
+	log := []string{}
+	logger := newLifecycleComponent("logger", &log)
+	persistence := newLifecycleComponent("persistence", &log)
+	tenantPersistence := newLifecycleComponent("tenant_persistence", &log,
+		"logger", refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0"),
+	)
+	controller := newLifecycleComponent("controller", &log,
+		"persistence", refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"),
+	)
+
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), persistence,
+	)
+	scope := refer.NewScopedReferencesFromTuples(references,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "tenant1", "1.0"), tenantPersistence,
+		refer.NewDescriptor("mygroup", "controller", "default", "tenant1", "1.0"), controller,
+	)
+
+	graph := refer.BuildDependencyGraph(scope)
+	assert.Len(t, graph.Nodes, 4)
+	assert.Equal(t, "pip-services:logger:console:default:1.0", graph.Nodes[0].Locator)
+	assert.Equal(t, "mygroup:persistence:memory:tenant1:1.0", graph.Nodes[2].Locator)
+
+	// The parent logger resolves the scoped dependency
+	assert.Len(t, graph.Edges, 2)
+	assert.Equal(t, "c2", graph.Edges[0].From)
+	assert.Equal(t, []string{"c0"}, graph.Edges[0].To)
+	assert.Equal(t, refer.DependencyResolved, graph.Edges[0].Status)
+
+	// The scoped persistence shadows the parent one
+	assert.Equal(t, "c3", graph.Edges[1].From)
+	assert.Equal(t, []string{"c2"}, graph.Edges[1].To)
+	assert.Equal(t, refer.DependencyResolved, graph.Edges[1].Status)
+	assert.Len(t, graph.GetUnresolved(), 0)
+}
+
+func TestExportDependencyGraph(t *testing.T) {
+	log := []string{}
+	controller := newLifecycleComponent("controller", &log,
+		"persistence", refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"),
+	)
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("mygroup", "controller", "default", "default", "1.0"), controller,
+	)
+	references.PutFactory(
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"),
+		refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+			return "persistence", nil
+		}), refer.Singleton,
+	)
+
+	graph := refer.BuildDependencyGraph(references)
+	assert.Len(t, graph.Nodes, 2)
+	assert.Equal(t, "", graph.Nodes[1].Type)
+	assert.Equal(t, refer.DependencyResolved, graph.Edges[0].Status)
+
+	json, err := graph.ToJson()
+	assert.Nil(t, err)
+	value := convert.JsonConverter.ToMap(json)
+	assert.Len(t, value["nodes"], 2)
+	assert.Len(t, value["edges"], 1)
+
+	dot := graph.ToDot()
+	assert.True(t, strings.HasPrefix(dot, "digraph references {"))
+	assert.Contains(t, dot, "\"c0\" [label=\"mygroup:controller:default:default:1.0\\n*test_refer.lifecycleComponent\"];")
+	assert.Contains(t, dot, "\"c0\" -> \"c1\" [label=\"persistence\"];")
+
+	references.Remove(refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"))
+	dot = refer.BuildDependencyGraph(references).ToDot()
+	assert.Contains(t, dot, "\"c0\" -> \"u0\" [label=\"persistence\", style=dashed, color=red];")
+}
@@ -67,3 +67,31 @@ func TestDescriptorEquals(t *testing.T) {
 	match = descriptor1.Equals(descriptor3)
 	assert.False(t, match)
 }
+
+func TestDescriptorMatchVersionRange(t *testing.T) {
+	descriptor := refer.NewDescriptor("pip-dummies", "controller", "default", "default", "1.2")
+
+	assert.True(t, descriptor.Match(refer.NewDescriptor("pip-dummies", "controller", "*", "*", "^1.0")))
+	assert.True(t, descriptor.Match(refer.NewDescriptor("*", "*", "*", "*", ">=1.1 <2.0")))
+	assert.True(t, descriptor.Match(refer.NewDescriptor("*", "*", "*", "*", "1.x")))
+	assert.False(t, descriptor.Match(refer.NewDescriptor("*", "*", "*", "*", "1.0")))
+	assert.False(t, descriptor.Match(refer.NewDescriptor("*", "*", "*", "*", "^2.0")))
+
+	// Ranges match in both directions but never in exact match
+	locator := refer.NewDescriptor("pip-dummies", "controller", "default", "default", "^1.0")
+	assert.True(t, locator.Match(descriptor))
+	assert.False(t, locator.ExactMatch(descriptor))
+	assert.True(t, locator.ExactMatch(refer.NewDescriptor("pip-dummies", "controller", "default", "default", "^1.0")))
+}
+
+func TestDescriptorVersionRangeFromString(t *testing.T) {
+	descriptor, err := refer.ParseDescriptorFromString("pip-dummies:controller:*:*:>=1.1 <2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, ">=1.1 <2.0", descriptor.Version())
+	assert.Equal(t, "pip-dummies:controller:*:*:>=1.1 <2.0", descriptor.String())
+	assert.True(t, descriptor.Match(refer.NewDescriptor("pip-dummies", "controller", "default", "default", "1.5")))
+
+	descriptor, err = refer.ParseDescriptorFromString("pip-dummies:controller:*:*:^1.0")
+	assert.Nil(t, err)
+	assert.Equal(t, "pip-dummies:controller:*:*:^1.0", descriptor.String())
+}
@@ -0,0 +1,158 @@
+package test_refer
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+type lazyComponent struct {
+	id int32
+}
+
+func TestSingletonFactoryReference(t *testing.T) {
+	var created int32
+	refs := refer.NewEmptyReferences()
+	locator := refer.NewDescriptor("pip-services", "component", "lazy", "default", "1.0")
+	refs.PutFactory(locator, refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+		return &lazyComponent{id: atomic.AddInt32(&created, 1)}, nil
+	}), refer.Singleton)
+
+	// The component is not created until it is located
+	assert.Equal(t, 0, len(refs.GetAll()))
+	assert.Equal(t, 1, len(refs.GetAllLocators()))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&created))
+
+	var wg sync.WaitGroup
+	components := make([]interface{}, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			components[i], _ = refs.GetOneRequired(refer.NewDescriptor("*", "component", "*", "*", "*"))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&created))
+	for _, component := range components {
+		assert.Same(t, components[0], component)
+	}
+	assert.Equal(t, []interface{}{components[0]}, refs.GetAll())
+}
+
+func TestTransientFactoryReference(t *testing.T) {
+	var created int32
+	refs := refer.NewEmptyReferences()
+	refs.PutFactory("Component", refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+		return &lazyComponent{id: atomic.AddInt32(&created, 1)}, nil
+	}), refer.Transient)
+
+	component1 := refs.GetOneOptional("Component")
+	component2 := refs.GetOneOptional("Component")
+	assert.NotNil(t, component1)
+	assert.NotSame(t, component1, component2)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&created))
+	assert.Equal(t, 0, len(refs.GetAll()))
+}
+
+func TestFactoryReferenceErrors(t *testing.T) {
+	refs := refer.NewEmptyReferences()
+	refs.PutFactory("Failed", refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+		return nil, errors.NewConnectionError("", "NO_CONNECTION", "Connection failed")
+	}), refer.Singleton)
+	refs.PutFactory("Panicked", refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+		panic("Test error")
+	}), refer.Singleton)
+
+	_, err := refs.GetOneRequired("Failed")
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, errors.FailedInvocation, appErr.Category)
+	assert.Equal(t, "REF_CREATE_FAILED", appErr.Code)
+	assert.Equal(t, "Failed", appErr.Details["locator"])
+	assert.Equal(t, "Connection failed", appErr.Cause)
+	assert.Nil(t, refs.GetOneOptional("Failed"))
+
+	_, err = refs.GetOneRequired("Panicked")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Test error", err.(*errors.ApplicationError).Cause)
+}
+
+func TestFactoryUsesReferences(t *testing.T) {
+	refs := refer.NewReferencesFromTuples("Dependency", "ABC")
+	refs.PutFactory("Component", refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+		dependency, err := refs.GetOneRequired("Dependency")
+		if err != nil {
+			return nil, err
+		}
+		refs.Put("Created", true)
+		return "Component " + dependency.(string), nil
+	}), refer.Singleton)
+
+	component, err := refs.GetOneRequired("Component")
+	assert.Nil(t, err)
+	assert.Equal(t, "Component ABC", component)
+	assert.Equal(t, true, refs.GetOneOptional("Created"))
+}
+
+func TestGetOneResolvesFirstMatchingFactory(t *testing.T) {
+	var failed, created int32
+	refs := refer.NewEmptyReferences()
+	refs.PutFactory(refer.NewDescriptor("test", "component", "failed", "default", "1.0"),
+		refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+			atomic.AddInt32(&failed, 1)
+			return nil, errors.NewConnectionError("", "NO_CONNECTION", "Connection failed")
+		}), refer.Singleton)
+	refs.PutFactory(refer.NewDescriptor("test", "component", "lazy", "default", "1.0"),
+		refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+			return &lazyComponent{id: atomic.AddInt32(&created, 1)}, nil
+		}), refer.Singleton)
+	locator := refer.NewDescriptor("test", "component", "*", "*", "*")
+
+	// Only the first matching factory is called
+	component, err := refs.GetOneRequired(locator)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), component.(*lazyComponent).id)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&failed))
+
+	// Failed factories are skipped when other matches resolve
+	refs.Remove(refer.NewDescriptor("test", "component", "lazy", "default", "1.0"))
+	refs.Put(refer.NewDescriptor("test", "component", "static", "default", "1.0"), "Static")
+	refs.PutFactory(refer.NewDescriptor("test", "component", "failed2", "default", "1.0"),
+		refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+			return nil, errors.NewConnectionError("", "NO_CONNECTION", "Connection failed")
+		}), refer.Singleton)
+	assert.Equal(t, "Static", refs.GetOneOptional(locator))
+}
+
+func TestFactoryResolvesItself(t *testing.T) {
+	for _, scope := range []refer.ReferenceScope{refer.Singleton, refer.Transient} {
+		refs := refer.NewEmptyReferences()
+		refs.PutFactory("Component", refer.ReferenceFactoryFunc(func(locator interface{}) (interface{}, error) {
+			return refs.GetOneRequired("Component")
+		}), scope)
+
+		done := make(chan error)
+		go func() {
+			_, err := refs.GetOneRequired("Component")
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			assert.NotNil(t, err)
+			appErr := err.(*errors.ApplicationError)
+			assert.Equal(t, "REF_CREATE_FAILED", appErr.Code)
+			assert.Contains(t, appErr.Cause, "circular references")
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Resolving the factory reference hangs")
+			return
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package test_refer
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+type lifecycleComponent struct {
+	name       string
+	log        *[]string
+	resolver   *refer.DependencyResolver
+	opened     bool
+	referenced bool
+	failOpen   bool
+}
+
+func newLifecycleComponent(name string, log *[]string, dependencies ...interface{}) *lifecycleComponent {
+	return &lifecycleComponent{
+		name:     name,
+		log:      log,
+		resolver: refer.NewDependencyResolverFromTuples(dependencies...),
+	}
+}
+
+func (c *lifecycleComponent) GetDependencyResolver() *refer.DependencyResolver {
+	return c.resolver
+}
+
+func (c *lifecycleComponent) SetReferences(references refer.IReferences) {
+	c.resolver.SetReferences(references)
+	c.referenced = true
+}
+
+func (c *lifecycleComponent) UnsetReferences() {
+	c.referenced = false
+}
+
+func (c *lifecycleComponent) IsOpen() bool {
+	return c.opened
+}
+
+func (c *lifecycleComponent) Open(correlationId string) error {
+	if c.failOpen {
+		return errors.NewInvalidStateError("", "OPEN_FAILED", "Failed to open "+c.name)
+	}
+	c.opened = true
+	*c.log = append(*c.log, "open "+c.name)
+	return nil
+}
+
+func (c *lifecycleComponent) Close(correlationId string) error {
+	c.opened = false
+	*c.log = append(*c.log, "close "+c.name)
+	return nil
+}
+
+func TestLifecycleOpenInDependencyOrder(t *testing.T) {
+	log := []string{}
+	controller := newLifecycleComponent("controller", &log,
+		"persistence", refer.NewDescriptor("test", "persistence", "*", "*", "*"),
+	)
+	service := newLifecycleComponent("service", &log,
+		"controller", refer.NewDescriptor("test", "controller", "*", "*", "*"),
+	)
+	persistence := newLifecycleComponent("persistence", &log,
+		"connection", refer.NewDescriptor("test", "connection", "*", "*", "*"),
+	)
+	connection := newLifecycleComponent("connection", &log)
+
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("test", "service", "http", "default", "1.0"), service,
+		refer.NewDescriptor("test", "controller", "default", "default", "1.0"), controller,
+		refer.NewDescriptor("test", "persistence", "memory", "default", "1.0"), persistence,
+		refer.NewDescriptor("test", "connection", "memory", "default", "1.0"), connection,
+	)
+
+	manager := refer.NewLifecycleManager(refs)
+	err := manager.Open("123")
+	assert.Nil(t, err)
+	assert.True(t, manager.IsOpen())
+	assert.True(t, service.referenced)
+
+	err = manager.Close("123")
+	assert.Nil(t, err)
+	assert.False(t, manager.IsOpen())
+	assert.False(t, service.referenced)
+
+	assert.Equal(t, []string{
+		"open connection", "open persistence", "open controller", "open service",
+		"close service", "close controller", "close persistence", "close connection",
+	}, log)
+}
+
+func TestLifecycleRollbackOnOpenFailure(t *testing.T) {
+	log := []string{}
+	persistence := newLifecycleComponent("persistence", &log)
+	controller := newLifecycleComponent("controller", &log,
+		"persistence", refer.NewDescriptor("test", "persistence", "*", "*", "*"),
+	)
+	service := newLifecycleComponent("service", &log,
+		"controller", refer.NewDescriptor("test", "controller", "*", "*", "*"),
+	)
+	service.failOpen = true
+
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("test", "persistence", "memory", "default", "1.0"), persistence,
+		refer.NewDescriptor("test", "controller", "default", "default", "1.0"), controller,
+		refer.NewDescriptor("test", "service", "http", "default", "1.0"), service,
+	)
+
+	manager := refer.NewLifecycleManager(refs)
+	err := manager.Open("123")
+	assert.NotNil(t, err)
+	assert.False(t, manager.IsOpen())
+	assert.False(t, persistence.opened)
+	assert.False(t, controller.opened)
+
+	assert.Equal(t, []string{
+		"open persistence", "open controller",
+		"close controller", "close persistence",
+	}, log)
+}
+
+func TestLifecycleDetectCycles(t *testing.T) {
+	log := []string{}
+	component1 := newLifecycleComponent("component1", &log,
+		"dependency", refer.NewDescriptor("test", "component2", "*", "*", "*"),
+	)
+	component2 := newLifecycleComponent("component2", &log,
+		"dependency", refer.NewDescriptor("test", "component1", "*", "*", "*"),
+	)
+
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("test", "component1", "default", "default", "1.0"), component1,
+		refer.NewDescriptor("test", "component2", "default", "default", "1.0"), component2,
+	)
+
+	manager := refer.NewLifecycleManager(refs)
+	err := manager.Open("123")
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "REF_ERROR", appErr.Code)
+	cycle := appErr.Details["cycle"].([]string)
+	assert.Equal(t, 3, len(cycle))
+	assert.Equal(t, cycle[0], cycle[2])
+	assert.ElementsMatch(t, []string{
+		"test:component1:default:default:1.0", "test:component2:default:default:1.0",
+	}, cycle[:2])
+	assert.False(t, manager.IsOpen())
+	assert.Equal(t, 0, len(log))
+}
+
+func TestLifecycleWithNotComparableComponents(t *testing.T) {
+	log := []string{}
+	settings := map[string]interface{}{"host": "localhost"}
+	hosts := []string{"host1", "host2"}
+	controller := newLifecycleComponent("controller", &log,
+		"settings", refer.NewDescriptor("test", "settings", "*", "*", "*"),
+		"hosts", refer.NewDescriptor("test", "hosts", "*", "*", "*"),
+	)
+
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("test", "controller", "default", "default", "1.0"), controller,
+		refer.NewDescriptor("test", "settings", "default", "default", "1.0"), settings,
+		refer.NewDescriptor("test", "settings", "backup", "default", "1.0"), map[string]interface{}{"host": "backup"},
+		refer.NewDescriptor("test", "hosts", "default", "default", "1.0"), hosts,
+	)
+
+	manager := refer.NewLifecycleManager(refs)
+	err := manager.Open("123")
+	assert.Nil(t, err)
+	assert.True(t, manager.IsOpen())
+	assert.Equal(t, []string{"open controller"}, log)
+
+	err = manager.Close("123")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,74 @@
+package test_refer
+
+import (
+	"testing"
+
+	conf "github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternDescriptorMatch(t *testing.T) {
+	descriptor := refer.NewDescriptor("pip-services", "logger", "cloudwatch", "tenant-15", "1.0")
+
+	locator, err := refer.NewPatternDescriptor("*", "logger", "cloud*", "*", "*")
+	assert.Nil(t, err)
+	assert.True(t, locator.Match(descriptor))
+	assert.True(t, locator.Equals(descriptor))
+	assert.True(t, descriptor.Equals(locator))
+	assert.False(t, locator.Match(refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0")))
+
+	locator, _ = refer.NewPatternDescriptor("pip-*", "*", "*", "tenant-??", "1.?")
+	assert.True(t, locator.Match(descriptor))
+	assert.False(t, locator.Match(refer.NewDescriptor("pip-services", "logger", "cloudwatch", "tenant-150", "1.0")))
+
+	locator, _ = refer.NewPatternDescriptor("*", "[lc]ogger", "[!x]*", "/^tenant-\\d+$/", "^1.0")
+	assert.True(t, locator.Match(descriptor))
+	assert.False(t, locator.Match(refer.NewDescriptor("pip-services", "logger", "cloudwatch", "tenant-abc", "1.0")))
+
+	// Wildcards in descriptors still match any pattern
+	assert.True(t, locator.Match(refer.NewDescriptor("*", "*", "*", "*", "*")))
+
+	_, err = refer.NewPatternDescriptor("*", "*", "*", "/[a-/", "*")
+	assert.NotNil(t, err)
+}
+
+func TestPatternDescriptorFromString(t *testing.T) {
+	assert.True(t, refer.IsPatternDescriptorString("*:logger:cloud*:*:1.0"))
+	assert.True(t, refer.IsPatternDescriptorString("*:logger:*:/^tenant-\\d+$/:1.0"))
+	assert.False(t, refer.IsPatternDescriptorString("*:logger:*:*:1.*"))
+	assert.False(t, refer.IsPatternDescriptorString("*:logger:*:*"))
+
+	locator, err := refer.ParsePatternDescriptorFromString("*:logger:cloud*:tenant-?:*")
+	assert.Nil(t, err)
+	assert.Equal(t, "cloud*", locator.Kind())
+	assert.Equal(t, "*:logger:cloud*:tenant-?:*", locator.String())
+
+	_, err = refer.ParsePatternDescriptorFromString("xxx")
+	assert.NotNil(t, err)
+}
+
+func TestFindReferencesByPattern(t *testing.T) {
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "cloudwatch", "default", "1.0"), "Logger1",
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), "Logger2",
+		refer.NewDescriptor("pip-services", "logger", "cloudlogging", "default", "1.0"), "Logger3",
+	)
+
+	locator, _ := refer.NewPatternDescriptor("*", "logger", "cloud*", "*", "1.0")
+	assert.Equal(t, []interface{}{"Logger3", "Logger1"}, refs.GetOptional(locator))
+
+	config := conf.NewConfigParamsFromTuples(
+		"dependencies.loggers", "*:logger:cloud*:*:1.0",
+		"dependencies.console", "*:logger:console:*:1.*",
+	)
+	resolver := refer.NewDependencyResolverWithParams(config, refs)
+
+	loggers, err := resolver.GetRequired("loggers")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"Logger3", "Logger1"}, loggers)
+
+	logger, err := resolver.GetOneRequired("console")
+	assert.Nil(t, err)
+	assert.Equal(t, "Logger2", logger)
+}
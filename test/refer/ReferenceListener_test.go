@@ -0,0 +1,100 @@
+package test_refer
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+type referenceChangeRecord struct {
+	change    refer.ReferenceChange
+	locator   interface{}
+	component interface{}
+}
+
+type referenceChangeRecorder struct {
+	changes []referenceChangeRecord
+}
+
+func (c *referenceChangeRecorder) OnReferenceChanged(change refer.ReferenceChange, locator interface{}, component interface{}) {
+	c.changes = append(c.changes, referenceChangeRecord{change, locator, component})
+}
+
+func TestReferenceListenerNotifications(t *testing.T) {
+	references := refer.NewEmptyReferences()
+	listener := &referenceChangeRecorder{}
+	references.AddListener(listener)
+
+	references.Put("logger1", "Console logger")
+	references.Put("logger2", "File logger")
+	references.Put("counters", "Log counters")
+
+	assert.Len(t, listener.changes, 3)
+	assert.Equal(t, refer.ReferenceAdded, listener.changes[0].change)
+	assert.Equal(t, "logger1", listener.changes[0].locator)
+	assert.Equal(t, "Console logger", listener.changes[0].component)
+
+	references.Remove("counters")
+	assert.Len(t, listener.changes, 4)
+	assert.Equal(t, refer.ReferenceRemoved, listener.changes[3].change)
+	assert.Equal(t, "counters", listener.changes[3].locator)
+	assert.Equal(t, "Log counters", listener.changes[3].component)
+
+	references.Remove("unknown")
+	assert.Len(t, listener.changes, 4)
+
+	references.RemoveListener(listener)
+	references.Put("tracer", "Tracer")
+	assert.Len(t, listener.changes, 4)
+}
+
+func TestReferenceListenerOnRemoveAll(t *testing.T) {
+	descriptor := refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0")
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), "Console logger",
+		refer.NewDescriptor("pip-services", "logger", "file", "default", "1.0"), "File logger",
+		refer.NewDescriptor("pip-services", "counters", "log", "default", "1.0"), "Log counters",
+	)
+
+	removed := []interface{}{}
+	listener := refer.ReferenceListenerFunc(func(change refer.ReferenceChange, locator interface{}, component interface{}) {
+		assert.Equal(t, refer.ReferenceRemoved, change)
+		removed = append(removed, component)
+	})
+	references.AddListener(&listener)
+
+	references.RemoveAll(descriptor)
+	assert.Equal(t, []interface{}{"File logger", "Console logger"}, removed)
+
+	references.RemoveListener(&listener)
+	references.RemoveAll(refer.NewDescriptor("pip-services", "counters", "*", "*", "1.0"))
+	assert.Len(t, removed, 2)
+}
+
+func TestReferenceListenerCanUseReferences(t *testing.T) {
+	references := refer.NewEmptyReferences()
+	var logger interface{}
+	listener := refer.ReferenceListenerFunc(func(change refer.ReferenceChange, locator interface{}, component interface{}) {
+		logger = references.GetOneOptional("logger")
+	})
+	references.AddListener(listener)
+
+	references.Put("logger", "Console logger")
+	assert.Equal(t, "Console logger", logger)
+
+	references.Remove("logger")
+	assert.Nil(t, logger)
+}
+
+func TestScopedReferencesNotifyOnClose(t *testing.T) {
+	parent := refer.NewEmptyReferences()
+	scope := refer.NewScopedReferencesFromTuples(parent, "logger", "Console logger")
+	listener := &referenceChangeRecorder{}
+	scope.AddListener(listener)
+
+	scope.Close("123")
+	assert.Len(t, listener.changes, 1)
+	assert.Equal(t, refer.ReferenceRemoved, listener.changes[0].change)
+	assert.Equal(t, "logger", listener.changes[0].locator)
+}
@@ -0,0 +1,32 @@
+package test_refer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckReferencesHealth(t *testing.T) {
+	log := []string{}
+	logger := newLifecycleComponent("logger", &log)
+	persistence := newLifecycleComponent("persistence", &log)
+	persistence.opened = true
+
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), persistence,
+		"config", "not checkable",
+	)
+
+	report := refer.CheckReferencesHealth(context.Background(), references, time.Second)
+	assert.Equal(t, run.HealthDown, report.Status)
+	assert.Len(t, report.Components, 2)
+	assert.Equal(t, "mygroup:persistence:memory:default:1.0", report.Components[0].Name)
+	assert.Equal(t, run.HealthUp, report.Components[0].Status)
+	assert.Equal(t, "pip-services:logger:console:default:1.0", report.Components[1].Name)
+	assert.Equal(t, run.HealthDown, report.Components[1].Status)
+}
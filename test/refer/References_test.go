@@ -0,0 +1,177 @@
+package test_refer
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutAndGetReferences(t *testing.T) {
+	refs := refer.NewEmptyReferences()
+	refs.Put("Reference1", "AAA")
+	refs.Put(refer.NewDescriptor("pip-services-commons", "reference", "object", "ref2", "1.0"), "BBB")
+	refs.Put(refer.NewDescriptor("pip-services-commons", "reference", "object", "ref3", "1.0"), "CCC")
+
+	assert.Equal(t, 3, len(refs.GetAll()))
+	assert.Equal(t, 3, len(refs.GetAllLocators()))
+
+	assert.Equal(t, "AAA", refs.GetOneOptional("Reference1"))
+
+	// The last registered reference is returned first
+	ref, err := refs.GetOneRequired(refer.NewDescriptor("pip-services-commons", "reference", "*", "*", "*"))
+	assert.Nil(t, err)
+	assert.Equal(t, "CCC", ref)
+
+	items, err := refs.GetRequired(refer.NewDescriptor("*", "reference", "*", "*", "*"))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"CCC", "BBB"}, items)
+
+	_, err = refs.GetOneRequired("Reference2")
+	assert.NotNil(t, err)
+}
+
+func TestRemoveReferences(t *testing.T) {
+	refs := refer.NewReferencesFromTuples(
+		"Reference1", "AAA",
+		refer.NewDescriptor("pip-services-commons", "reference", "object", "ref2", "1.0"), "BBB",
+		refer.NewDescriptor("pip-services-commons", "reference", "object", "ref3", "1.0"), "CCC",
+	)
+
+	removed := refs.Remove("Reference1")
+	assert.Equal(t, "AAA", removed)
+	assert.Nil(t, refs.GetOneOptional("Reference1"))
+
+	removedAll := refs.RemoveAll(refer.NewDescriptor("pip-services-commons", "*", "*", "*", "*"))
+	assert.Equal(t, 2, len(removedAll))
+	assert.Equal(t, 0, len(refs.GetAll()))
+}
+
+func TestConcurrentReferences(t *testing.T) {
+	refs := refer.NewEmptyReferences()
+	locator := refer.NewDescriptor("pip-services-commons", "reference", "*", "*", "1.0")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				name := fmt.Sprintf("ref%d_%d", i, j)
+				descriptor := refer.NewDescriptor("pip-services-commons", "reference", "object", name, "1.0")
+				refs.Put(descriptor, name)
+				if j%2 == 0 {
+					refs.Remove(descriptor)
+				}
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				refs.GetOneOptional(locator)
+				refs.GetOptional(locator)
+				refs.GetAll()
+				refs.GetAllLocators()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 500, len(refs.GetOptional(locator)))
+
+	removed := refs.RemoveAll(locator)
+	assert.Equal(t, 500, len(removed))
+	assert.Equal(t, 0, len(refs.GetAll()))
+}
+
+func TestFindReferencesByDescriptorIndex(t *testing.T) {
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), "Logger1",
+		refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0"), "Logger2",
+		"Logger3", "Logger3",
+		refer.NewDescriptor("pip-services", "counters", "log", "default", "1.0"), "Counters1",
+		refer.NewDescriptor("pip-services", "logger", "cloudwatch", "default", "1.0"), "Logger4",
+	)
+
+	// Wildcard fields on both sides match and newest registrations come first
+	loggers := refs.GetOptional(refer.NewDescriptor("*", "logger", "console", "*", "*"))
+	assert.Equal(t, []interface{}{"Logger2", "Logger1"}, loggers)
+
+	loggers = refs.GetOptional(refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0"))
+	assert.Equal(t, []interface{}{"Logger4", "Logger2", "Logger1"}, loggers)
+
+	all := refs.GetOptional(refer.NewDescriptor("*", "*", "*", "*", "*"))
+	assert.Equal(t, []interface{}{"Logger4", "Counters1", "Logger2", "Logger1"}, all)
+
+	assert.Equal(t, 0, len(refs.GetOptional(refer.NewDescriptor("*", "*", "*", "*", "2.0"))))
+
+	// Index is updated on removal
+	refs.Remove(refer.NewDescriptor("*", "logger", "cloudwatch", "*", "*"))
+	assert.Equal(t, "Logger2", refs.GetOneOptional(refer.NewDescriptor("*", "logger", "*", "*", "*")))
+
+	refs.RemoveAll(refer.NewDescriptor("*", "logger", "*", "*", "*"))
+	assert.Nil(t, refs.GetOneOptional(refer.NewDescriptor("*", "logger", "*", "*", "*")))
+	assert.Equal(t, "Counters1", refs.GetOneOptional(refer.NewDescriptor("*", "*", "log", "*", "*")))
+	assert.Equal(t, "Logger3", refs.GetOneOptional("Logger3"))
+}
+
+func newBenchmarkReferences(count int) *refer.References {
+	refs := refer.NewEmptyReferences()
+	for i := 0; i < count; i++ {
+		descriptor := refer.NewDescriptor("pip-services", fmt.Sprintf("type%d", i%50), "default", fmt.Sprintf("name%d", i), "1.0")
+		refs.Put(descriptor, i)
+	}
+	return refs
+}
+
+// Reproduces the linear scan that References used before lookups were indexed
+func scanReferences(references []*refer.Reference, locator interface{}) []interface{} {
+	components := make([]interface{}, 0, 2)
+	for index := len(references) - 1; index >= 0; index-- {
+		if references[index].Match(locator) {
+			components = append(components, references[index].Component())
+		}
+	}
+	return components
+}
+
+func BenchmarkFindReferencesIndexed(b *testing.B) {
+	refs := newBenchmarkReferences(1000)
+	locator := refer.NewDescriptor("pip-services", "type10", "*", "name510", "*")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		refs.GetOneOptional(locator)
+	}
+}
+
+func BenchmarkFindReferencesScan(b *testing.B) {
+	references := make([]*refer.Reference, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		descriptor := refer.NewDescriptor("pip-services", fmt.Sprintf("type%d", i%50), "default", fmt.Sprintf("name%d", i), "1.0")
+		references = append(references, refer.NewReference(descriptor, i))
+	}
+	locator := refer.NewDescriptor("pip-services", "type10", "*", "name510", "*")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scanReferences(references, locator)
+	}
+}
+
+func TestFindReferencesByVersionRange(t *testing.T) {
+	refs := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.2"), "Persistence1",
+		refer.NewDescriptor("mygroup", "persistence", "mongodb", "default", "2.0"), "Persistence2",
+		refer.NewDescriptor("mygroup", "controller", "default", "default", "^1.0"), "Controller1",
+	)
+
+	assert.Equal(t, []interface{}{"Persistence1"}, refs.GetOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", "^1.0")))
+	assert.Equal(t, []interface{}{"Persistence2", "Persistence1"}, refs.GetOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", ">=1.0")))
+	assert.Equal(t, 0, len(refs.GetOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"))))
+	assert.Equal(t, "Controller1", refs.GetOneOptional(refer.NewDescriptor("*", "*", "*", "*", "1.5")))
+}
@@ -0,0 +1,74 @@
+package test_refer
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+type scopedComponent struct {
+	closed bool
+}
+
+func (c *scopedComponent) Close(correlationId string) error {
+	c.closed = true
+	return nil
+}
+
+func TestScopedReferencesFallbackToParent(t *testing.T) {
+	parentLogger := &scopedComponent{}
+	parentPersistence := &scopedComponent{}
+	parent := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), parentLogger,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), parentPersistence,
+	)
+
+	tenantPersistence := &scopedComponent{}
+	scope := refer.NewScopedReferencesFromTuples(parent,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "tenant1", "1.0"), tenantPersistence,
+	)
+
+	// Scope components shadow parent components
+	persistence, err := scope.GetOneRequired(refer.NewDescriptor("mygroup", "persistence", "*", "*", "1.0"))
+	assert.Nil(t, err)
+	assert.Same(t, tenantPersistence, persistence)
+	assert.Equal(t, []interface{}{tenantPersistence}, scope.GetOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", "*")))
+
+	// Missing components are found in parent
+	logger, err := scope.GetOneRequired(refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0"))
+	assert.Nil(t, err)
+	assert.Same(t, parentLogger, logger)
+
+	_, err = scope.GetOneRequired(refer.NewDescriptor("pip-services", "counters", "*", "*", "1.0"))
+	assert.NotNil(t, err)
+
+	// Only scope components are listed
+	assert.Equal(t, []interface{}{tenantPersistence}, scope.GetAll())
+
+	// Nested scopes fall back through the chain
+	requestScope := scope.CreateScope()
+	assert.Same(t, tenantPersistence, requestScope.GetOneOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", "*")))
+	assert.Same(t, parentLogger, requestScope.GetOneOptional(refer.NewDescriptor("pip-services", "logger", "*", "*", "*")))
+}
+
+func TestCloseScopedReferences(t *testing.T) {
+	parentPersistence := &scopedComponent{}
+	parent := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("mygroup", "persistence", "memory", "default", "1.0"), parentPersistence,
+	)
+
+	tenantPersistence := &scopedComponent{}
+	scope := refer.NewScopedReferencesFromTuples(parent,
+		refer.NewDescriptor("mygroup", "persistence", "memory", "tenant1", "1.0"), tenantPersistence,
+	)
+
+	err := scope.Close("123")
+	assert.Nil(t, err)
+	assert.True(t, tenantPersistence.closed)
+	assert.False(t, parentPersistence.closed)
+
+	assert.Equal(t, 0, len(scope.GetAll()))
+	assert.Same(t, parentPersistence, scope.GetOneOptional(refer.NewDescriptor("mygroup", "persistence", "*", "*", "*")))
+	assert.Equal(t, 1, len(parent.GetAll()))
+}
@@ -0,0 +1,117 @@
+package test_refer
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+type typedLogger struct {
+	name string
+}
+
+func (c *typedLogger) Log(message string) string {
+	return c.name + ": " + message
+}
+
+type typedLoggerInterface interface {
+	Log(message string) string
+}
+
+func TestGetTypedReferences(t *testing.T) {
+	logger1 := &typedLogger{name: "console"}
+	logger2 := &typedLogger{name: "file"}
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger1,
+		refer.NewDescriptor("pip-services", "logger", "file", "default", "1.0"), logger2,
+		refer.NewDescriptor("pip-services", "counters", "log", "default", "1.0"), "not a logger",
+	)
+	locator := refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0")
+
+	logger, err := refer.GetOneRequired[typedLoggerInterface](references, locator)
+	assert.Nil(t, err)
+	assert.Equal(t, "file: test", logger.Log("test"))
+
+	concrete, err := refer.GetOneOptional[*typedLogger](references, locator)
+	assert.Nil(t, err)
+	assert.Equal(t, logger2, concrete)
+
+	loggers, err := refer.GetRequired[typedLoggerInterface](references, locator)
+	assert.Nil(t, err)
+	assert.Len(t, loggers, 2)
+
+	missing, err := refer.GetOneOptional[*typedLogger](references,
+		refer.NewDescriptor("pip-services", "tracer", "*", "*", "1.0"))
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+
+	_, err = refer.GetOneRequired[*typedLogger](references,
+		refer.NewDescriptor("pip-services", "tracer", "*", "*", "1.0"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "REF_ERROR", err.(*errors.ApplicationError).Code)
+
+	empty, err := refer.GetOptional[*typedLogger](references,
+		refer.NewDescriptor("pip-services", "tracer", "*", "*", "1.0"))
+	assert.Nil(t, err)
+	assert.Len(t, empty, 0)
+}
+
+func TestGetTypedReferencesWithWrongType(t *testing.T) {
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), "not a logger",
+	)
+	locator := refer.NewDescriptor("pip-services", "logger", "*", "*", "1.0")
+
+	_, err := refer.GetOneRequired[typedLoggerInterface](references, locator)
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "REF_ERROR", appErr.Code)
+	assert.Equal(t, errors.Internal, appErr.Category)
+	assert.Equal(t, "test_refer.typedLoggerInterface", appErr.Details["expected_type"])
+	assert.Equal(t, "string", appErr.Details["actual_type"])
+
+	_, err = refer.GetOneOptional[*typedLogger](references, locator)
+	assert.NotNil(t, err)
+
+	_, err = refer.GetOptional[*typedLogger](references, locator)
+	assert.NotNil(t, err)
+}
+
+func TestGetTypedDependencies(t *testing.T) {
+	logger := &typedLogger{name: "console"}
+	references := refer.NewReferencesFromTuples(
+		refer.NewDescriptor("pip-services", "logger", "console", "default", "1.0"), logger,
+		refer.NewDescriptor("pip-services", "counters", "log", "default", "1.0"), 123,
+	)
+
+	resolver := refer.NewDependencyResolverWithParams(
+		config.NewConfigParamsFromTuples(
+			"dependencies.logger", "pip-services:logger:*:*:1.0",
+			"dependencies.counters", "pip-services:counters:*:*:1.0",
+		),
+		references,
+	)
+
+	result, err := refer.GetOneRequiredDependency[typedLoggerInterface](resolver, "logger")
+	assert.Nil(t, err)
+	assert.Equal(t, logger, result)
+
+	loggers, err := refer.GetRequiredDependencies[*typedLogger](resolver, "logger")
+	assert.Nil(t, err)
+	assert.Len(t, loggers, 1)
+
+	_, err = refer.GetOneRequiredDependency[typedLoggerInterface](resolver, "counters")
+	assert.NotNil(t, err)
+	assert.Equal(t, "REF_ERROR", err.(*errors.ApplicationError).Code)
+
+	missing, err := refer.GetOneOptionalDependency[*typedLogger](resolver, "tracer")
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+
+	optional, err := refer.GetOptionalDependencies[*typedLogger](resolver, "tracer")
+	assert.Nil(t, err)
+	assert.Len(t, optional, 0)
+}
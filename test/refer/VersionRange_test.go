@@ -0,0 +1,48 @@
+package test_refer
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/refer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionRangeContains(t *testing.T) {
+	versionRange, err := refer.ParseVersionRange("^1.0")
+	assert.Nil(t, err)
+	assert.True(t, versionRange.Contains("1.0"))
+	assert.True(t, versionRange.Contains("1.2"))
+	assert.True(t, versionRange.Contains("1.9.3"))
+	assert.False(t, versionRange.Contains("2.0"))
+	assert.False(t, versionRange.Contains("0.9"))
+
+	versionRange, _ = refer.ParseVersionRange("^0.2")
+	assert.True(t, versionRange.Contains("0.2.5"))
+	assert.False(t, versionRange.Contains("0.3"))
+
+	versionRange, _ = refer.ParseVersionRange("~1.2")
+	assert.True(t, versionRange.Contains("1.2.7"))
+	assert.False(t, versionRange.Contains("1.3"))
+
+	versionRange, _ = refer.ParseVersionRange(">=1.1 <2.0")
+	assert.False(t, versionRange.Contains("1.0"))
+	assert.True(t, versionRange.Contains("1.1"))
+	assert.True(t, versionRange.Contains("1.99"))
+	assert.False(t, versionRange.Contains("2.0"))
+
+	versionRange, _ = refer.ParseVersionRange("1.x")
+	assert.True(t, versionRange.Contains("1"))
+	assert.True(t, versionRange.Contains("1.5.1"))
+	assert.False(t, versionRange.Contains("2.0"))
+
+	versionRange, _ = refer.ParseVersionRange("1.x || >= 3.0")
+	assert.True(t, versionRange.Contains("1.1"))
+	assert.False(t, versionRange.Contains("2.1"))
+	assert.True(t, versionRange.Contains("3.2"))
+	assert.False(t, versionRange.Contains("default"))
+
+	_, err = refer.ParseVersionRange(">=abc")
+	assert.NotNil(t, err)
+	_, err = refer.ParseVersionRange("1.x.2")
+	assert.NotNil(t, err)
+}
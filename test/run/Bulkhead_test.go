@@ -0,0 +1,108 @@
+package test_run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkheadRejectsWhenQueueIsFull(t *testing.T) {
+	bulkhead := run.NewBulkhead(1, 0, time.Second)
+	ctx := context.Background()
+
+	assert.Nil(t, bulkhead.Acquire(ctx))
+	assert.Equal(t, 1, bulkhead.Running())
+
+	err := bulkhead.Acquire(ctx)
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "BULKHEAD_FULL", appErr.Code)
+	assert.Equal(t, errors.InvalidState, appErr.Category)
+	assert.Equal(t, int64(1000), appErr.Details["retry_after"])
+
+	bulkhead.Release()
+	assert.Equal(t, 0, bulkhead.Running())
+	assert.Nil(t, bulkhead.Acquire(ctx))
+	bulkhead.Release()
+}
+
+func TestBulkheadQueuesCalls(t *testing.T) {
+	bulkhead := run.NewBulkhead(1, 1, 0)
+	bulkhead.Configure(config.NewConfigParamsFromTuples(
+		"bulkhead.queue_timeout", 5000,
+	))
+	ctx := context.Background()
+
+	assert.Nil(t, bulkhead.Acquire(ctx))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- bulkhead.Acquire(ctx)
+	}()
+	for bulkhead.Queued() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The queue is full
+	err := bulkhead.Acquire(ctx)
+	assert.Equal(t, "BULKHEAD_FULL", err.(*errors.ApplicationError).Code)
+
+	bulkhead.Release()
+	assert.Nil(t, <-acquired)
+	assert.Equal(t, 0, bulkhead.Queued())
+	bulkhead.Release()
+}
+
+func TestBulkheadQueueTimeout(t *testing.T) {
+	bulkhead := run.NewBulkhead(1, 1, 50*time.Millisecond)
+	ctx := context.Background()
+
+	assert.Nil(t, bulkhead.Acquire(ctx))
+	defer bulkhead.Release()
+
+	start := time.Now()
+	_, err := bulkhead.Call(ctx, func() (interface{}, error) {
+		return "OK", nil
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "BULKHEAD_FULL", err.(*errors.ApplicationError).Code)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, 0, bulkhead.Queued())
+}
+
+func TestBulkheadReconfigureWhileRunning(t *testing.T) {
+	bulkhead := run.NewBulkhead(2, 1, 0)
+	ctx := context.Background()
+
+	assert.Nil(t, bulkhead.Acquire(ctx))
+	assert.Nil(t, bulkhead.Acquire(ctx))
+
+	// Running calls are kept when the limit is decreased
+	bulkhead.Configure(config.NewConfigParamsFromTuples("bulkhead.max_concurrent", 1))
+	assert.Equal(t, 2, bulkhead.Running())
+	bulkhead.Release()
+	assert.Equal(t, 1, bulkhead.Running())
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- bulkhead.Acquire(ctx)
+	}()
+	for bulkhead.Queued() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Waiting calls take new slots when the limit is increased
+	bulkhead.Configure(config.NewConfigParamsFromTuples("bulkhead.max_concurrent", 2))
+	assert.Nil(t, <-acquired)
+	assert.Equal(t, 2, bulkhead.Running())
+
+	bulkhead.Release()
+	bulkhead.Release()
+	bulkhead.Release()
+	assert.Equal(t, 0, bulkhead.Running())
+}
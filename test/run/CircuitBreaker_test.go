@@ -0,0 +1,173 @@
+package test_run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type circuitStateRecorder struct {
+	changes []string
+}
+
+func (c *circuitStateRecorder) Notify(correlationId string, args *run.Parameters) {
+	c.changes = append(c.changes, args.GetAsString("from")+"->"+args.GetAsString("to"))
+}
+
+func newTestCircuitBreaker(clock run.IClock) (*run.CircuitBreaker, *circuitStateRecorder) {
+	breaker := run.NewCircuitBreaker("test")
+	breaker.Configure(config.NewConfigParamsFromTuples(
+		"circuit.window", 10000,
+		"circuit.min_requests", 4,
+		"circuit.failure_rate", 0.5,
+		"circuit.open_timeout", 5000,
+		"circuit.half_open_requests", 2,
+	))
+	breaker.SetClock(clock)
+	recorder := &circuitStateRecorder{}
+	breaker.SetEvent(recorder)
+	return breaker, recorder
+}
+
+func failingCall() (interface{}, error) {
+	return nil, errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused")
+}
+
+func successfulCall() (interface{}, error) {
+	return "OK", nil
+}
+
+func TestCircuitBreakerOpensOnFailureRate(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	breaker, recorder := newTestCircuitBreaker(clock)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+
+	breaker.Call("123", successfulCall)
+	breaker.Call("123", failingCall)
+	breaker.Call("123", successfulCall)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+
+	// Bad requests mean the dependency is working
+	breaker.Call("123", func() (interface{}, error) {
+		return nil, errors.NewBadRequestError("123", "BAD_REQUEST", "Bad request")
+	})
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+
+	breaker.Call("123", failingCall)
+	breaker.Call("123", failingCall)
+	assert.Equal(t, run.CircuitOpen, breaker.State())
+	assert.Equal(t, []string{"closed->open"}, recorder.changes)
+
+	called := false
+	_, err := breaker.Call("123", func() (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	assert.False(t, called)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "CIRCUIT_OPEN", appErr.Code)
+	assert.Equal(t, errors.NoResponse, appErr.Category)
+	assert.Equal(t, int64(5000), appErr.Details["retry_after"])
+}
+
+func TestCircuitBreakerSlidingWindow(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	breaker, _ := newTestCircuitBreaker(clock)
+
+	breaker.Call("123", failingCall)
+	breaker.Call("123", failingCall)
+	breaker.Call("123", failingCall)
+
+	// Old failures leave the window
+	clock.Advance(11 * time.Second)
+	breaker.Call("123", failingCall)
+	breaker.Call("123", successfulCall)
+	breaker.Call("123", successfulCall)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	breaker, recorder := newTestCircuitBreaker(clock)
+
+	for index := 0; index < 4; index++ {
+		breaker.Call("123", failingCall)
+	}
+	assert.Equal(t, run.CircuitOpen, breaker.State())
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, run.CircuitHalfOpen, breaker.State())
+
+	// Failed trial opens the breaker again
+	breaker.Call("123", failingCall)
+	assert.Equal(t, run.CircuitOpen, breaker.State())
+
+	clock.Advance(5 * time.Second)
+	result, err := breaker.Call("123", successfulCall)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	assert.Equal(t, run.CircuitHalfOpen, breaker.State())
+
+	breaker.Call("123", successfulCall)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed"}, recorder.changes)
+}
+
+func TestCircuitBreakerWithoutHalfOpenRequests(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	breaker, _ := newTestCircuitBreaker(clock)
+	breaker.Configure(config.NewConfigParamsFromTuples(
+		"circuit.half_open_requests", 0,
+	))
+
+	for index := 0; index < 4; index++ {
+		breaker.Call("123", failingCall)
+	}
+	clock.Advance(5 * time.Second)
+
+	// One trial call is required when the setting is not positive
+	_, err := breaker.Call("123", successfulCall)
+	assert.Nil(t, err)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerPanicInHalfOpen(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	breaker, _ := newTestCircuitBreaker(clock)
+
+	for index := 0; index < 4; index++ {
+		breaker.Call("123", failingCall)
+	}
+	clock.Advance(5 * time.Second)
+	assert.Equal(t, run.CircuitHalfOpen, breaker.State())
+
+	// Panic is passed to the caller and counted as a failed trial
+	assert.Panics(t, func() {
+		breaker.Call("123", func() (interface{}, error) {
+			panic("Test error")
+		})
+	})
+	assert.Equal(t, run.CircuitOpen, breaker.State())
+
+	// The trial slot is released, so the breaker recovers
+	clock.Advance(5 * time.Second)
+	breaker.Call("123", successfulCall)
+	breaker.Call("123", successfulCall)
+	assert.Equal(t, run.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerExecutesComponents(t *testing.T) {
+	breaker := run.NewCircuitBreaker("test")
+	component := &flakyComponent{}
+
+	result, err := breaker.ExecuteOne("123", component, run.NewEmptyParameters())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	assert.Equal(t, "test", breaker.Name())
+}
@@ -0,0 +1,128 @@
+package test_run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextComponent struct {
+	opened        bool
+	correlationId string
+	cleared       bool
+	notified      bool
+}
+
+func (c *contextComponent) IsOpen() bool {
+	return c.opened
+}
+
+func (c *contextComponent) Open(ctx context.Context) error {
+	c.opened = true
+	c.correlationId = run.GetCorrelationId(ctx)
+	return nil
+}
+
+func (c *contextComponent) Close(ctx context.Context) error {
+	c.opened = false
+	return nil
+}
+
+func (c *contextComponent) Execute(ctx context.Context, args *run.Parameters) (interface{}, error) {
+	return run.GetCorrelationId(ctx) + ":" + args.GetAsString("message"), nil
+}
+
+func (c *contextComponent) Clear(ctx context.Context) error {
+	c.cleared = true
+	return nil
+}
+
+func (c *contextComponent) Notify(ctx context.Context, args *run.Parameters) {
+	c.notified = true
+}
+
+type correlationComponent struct {
+	opened        bool
+	correlationId string
+}
+
+func (c *correlationComponent) IsOpen() bool {
+	return c.opened
+}
+
+func (c *correlationComponent) Open(correlationId string) error {
+	c.opened = true
+	c.correlationId = correlationId
+	return nil
+}
+
+func (c *correlationComponent) Close(correlationId string) error {
+	c.opened = false
+	return nil
+}
+
+func TestCorrelationIdInContext(t *testing.T) {
+	ctx := run.ContextWithCorrelationId(context.Background(), "123")
+	assert.Equal(t, "123", run.GetCorrelationId(ctx))
+	assert.Equal(t, "", run.GetCorrelationId(context.Background()))
+}
+
+func TestOpenContextComponents(t *testing.T) {
+	component1 := &contextComponent{}
+	component2 := &correlationComponent{}
+	components := []interface{}{component1, component2, "not a component"}
+
+	err := run.Opener.Open("123", components)
+	assert.Nil(t, err)
+	assert.True(t, run.Opener.IsOpen(components))
+	assert.Equal(t, "123", component1.correlationId)
+	assert.Equal(t, "123", component2.correlationId)
+
+	err = run.Closer.Close("123", components)
+	assert.Nil(t, err)
+	assert.False(t, run.Opener.IsOpenOne(component1))
+	assert.False(t, run.Opener.IsOpenOne(component2))
+
+	ctx := run.ContextWithCorrelationId(context.Background(), "456")
+	err = run.Opener.OpenWithContext(ctx, components)
+	assert.Nil(t, err)
+	assert.Equal(t, "456", component1.correlationId)
+	assert.Equal(t, "456", component2.correlationId)
+
+	err = run.Closer.CloseWithContext(ctx, components)
+	assert.Nil(t, err)
+	assert.False(t, run.Opener.IsOpenOne(component1))
+}
+
+func TestExecuteClearAndNotifyContextComponents(t *testing.T) {
+	component := &contextComponent{}
+
+	result, err := run.Executor.ExecuteOne("123", component, run.NewParametersFromTuples("message", "ABC"))
+	assert.Nil(t, err)
+	assert.Equal(t, "123:ABC", result)
+
+	err = run.Cleaner.ClearOne("123", component)
+	assert.Nil(t, err)
+	assert.True(t, component.cleared)
+
+	run.Notifier.NotifyOne("123", component, run.NewEmptyParameters())
+	assert.True(t, component.notified)
+}
+
+func TestCancelledContext(t *testing.T) {
+	component := &contextComponent{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := run.Opener.OpenOneWithContext(ctx, component)
+	assert.Equal(t, context.Canceled, err)
+	assert.False(t, component.opened)
+
+	_, err = run.Executor.ExecuteWithContext(ctx, []interface{}{component}, run.NewEmptyParameters())
+	assert.Equal(t, context.Canceled, err)
+
+	run.Notifier.NotifyWithContext(ctx, []interface{}{component}, run.NewEmptyParameters())
+	assert.False(t, component.notified)
+}
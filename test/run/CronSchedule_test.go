@@ -0,0 +1,52 @@
+package test_run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "0 2 * * *", "*/15 * * * MON-FRI",
+		"0 0 1,15 * ?", "10-30/5 8-18 * JAN-JUN 7", "@daily", "@hourly"} {
+		schedule, err := run.ParseCronSchedule(expression)
+		assert.Nil(t, err, expression)
+		assert.Equal(t, expression, schedule.String())
+	}
+
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "abc * * * *"} {
+		_, err := run.ParseCronSchedule(expression)
+		assert.NotNil(t, err, expression)
+		assert.Equal(t, "BAD_CRON_EXPRESSION", err.(*errors.ApplicationError).Code)
+	}
+}
+
+func TestNextCronScheduleTime(t *testing.T) {
+	start := time.Date(2024, time.March, 15, 10, 7, 30, 0, time.UTC) // Friday
+
+	schedule, _ := run.ParseCronSchedule("0 2 * * *")
+	assert.Equal(t, time.Date(2024, time.March, 16, 2, 0, 0, 0, time.UTC), schedule.Next(start))
+
+	schedule, _ = run.ParseCronSchedule("*/15 * * * MON-FRI")
+	assert.Equal(t, time.Date(2024, time.March, 15, 10, 15, 0, 0, time.UTC), schedule.Next(start))
+	assert.Equal(t, time.Date(2024, time.March, 18, 0, 0, 0, 0, time.UTC),
+		schedule.Next(time.Date(2024, time.March, 15, 23, 45, 0, 0, time.UTC)))
+
+	schedule, _ = run.ParseCronSchedule("@monthly")
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), schedule.Next(start))
+
+	schedule, _ = run.ParseCronSchedule("0 12 29 FEB *")
+	assert.Equal(t, time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC),
+		schedule.Next(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+
+	// Either day of month or day of week when both are restricted
+	schedule, _ = run.ParseCronSchedule("0 0 1 * SUN")
+	assert.Equal(t, time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC), schedule.Next(start))
+
+	schedule, _ = run.ParseCronSchedule("0 0 31 2 *")
+	assert.True(t, schedule.Next(start).IsZero())
+}
@@ -0,0 +1,119 @@
+package test_run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronTimerWithCallback(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 1, 30, 0, 0, time.UTC))
+	triggered := make(chan time.Time, 10)
+
+	timer, err := run.NewCronTimerFromCallback(func() {
+		triggered <- clock.Now()
+	}, "0 2 * * *")
+	assert.Nil(t, err)
+	timer.SetClock(clock)
+	timer.SetLocation(time.UTC)
+
+	timer.Start()
+	assert.True(t, timer.IsStarted())
+
+	clock.BlockUntil(1)
+	clock.Advance(29 * time.Minute)
+	clock.Advance(time.Minute)
+	assert.Equal(t, time.Date(2024, time.March, 15, 2, 0, 0, 0, time.UTC), <-triggered)
+
+	clock.BlockUntil(1)
+	clock.Advance(24 * time.Hour)
+	assert.Equal(t, time.Date(2024, time.March, 16, 2, 0, 0, 0, time.UTC), <-triggered)
+
+	timer.Close("")
+	assert.False(t, timer.IsStarted())
+	assert.Len(t, triggered, 0)
+}
+
+type cronTestTask struct {
+	notified chan string
+}
+
+func (c *cronTestTask) Notify(correlationId string, args *run.Parameters) {
+	c.notified <- correlationId
+}
+
+func TestCronTimerWithTask(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 7, 0, 0, time.UTC))
+	task := &cronTestTask{notified: make(chan string, 10)}
+
+	timer, err := run.NewCronTimerFromTask(task, "*/15 * * * *")
+	assert.Nil(t, err)
+	assert.Equal(t, task, timer.Task())
+	timer.SetClock(clock)
+	timer.SetLocation(time.UTC)
+
+	timer.Start()
+	clock.BlockUntil(1)
+	clock.Advance(8 * time.Minute)
+	assert.Equal(t, "timer", <-task.notified)
+
+	timer.Stop()
+	assert.False(t, timer.IsStarted())
+
+	_, err = run.NewCronTimerFromTask(task, "* * *")
+	assert.NotNil(t, err)
+}
+
+func TestCronTimerRecoversFromPanic(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 1, 30, 0, 0, time.UTC))
+	triggered := make(chan time.Time, 10)
+	calls := 0
+
+	timer, err := run.NewCronTimerFromCallback(func() {
+		calls++
+		if calls == 1 {
+			panic("Test panic")
+		}
+		triggered <- clock.Now()
+	}, "0 * * * *")
+	assert.Nil(t, err)
+	timer.SetClock(clock)
+	timer.SetLocation(time.UTC)
+	timer.Start()
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Minute)
+
+	// The timer waits for the next trigger after the panic
+	clock.BlockUntil(1)
+	assert.True(t, timer.IsStarted())
+	appErr, ok := timer.LastError().(*errors.ApplicationError)
+	assert.True(t, ok)
+	assert.Equal(t, "TIMER_FAILED", appErr.Code)
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, time.Date(2024, time.March, 15, 3, 0, 0, 0, time.UTC), <-triggered)
+
+	timer.Stop()
+}
+
+func TestCronTimerWithDefaultClock(t *testing.T) {
+	timer := &run.CronTimer{}
+	timer.SetCallback(func() {})
+	assert.Nil(t, timer.SetSchedule("0 2 * * *"))
+	timer.SetClock(nil)
+	timer.SetLocation(nil)
+
+	timer.Start()
+	assert.True(t, timer.IsStarted())
+	assert.NotNil(t, timer.Clock())
+	assert.Equal(t, time.Local, timer.Location())
+
+	// Gives the timer goroutine time to calculate the next trigger
+	time.Sleep(10 * time.Millisecond)
+	timer.Stop()
+	assert.False(t, timer.IsStarted())
+}
@@ -0,0 +1,59 @@
+package test_run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	clock := run.NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	after1 := clock.After(time.Minute)
+	after2 := clock.After(time.Hour)
+	assert.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(30 * time.Second)
+	assert.Len(t, after1, 0)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-after1)
+	assert.Equal(t, 1, clock.Waiters())
+
+	clock.Set(start)
+	assert.Len(t, after2, 0)
+
+	clock.Set(start.Add(2 * time.Hour))
+	assert.Equal(t, start.Add(2*time.Hour), <-after2)
+	assert.Equal(t, 0, clock.Waiters())
+
+	assert.Equal(t, start.Add(2*time.Hour), <-clock.After(0))
+}
+
+func TestFixedRateTimerWithFakeClock(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	triggered := make(chan time.Time, 10)
+
+	timer := run.NewFixedRateTimerFromCallback(func() {
+		triggered <- clock.Now()
+	}, 60000, 1000)
+	timer.SetClock(clock)
+	timer.Start()
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.Equal(t, time.Date(2024, time.March, 15, 10, 0, 1, 0, time.UTC), <-triggered)
+
+	for index := 1; index <= 3; index++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		assert.Equal(t, time.Date(2024, time.March, 15, 10, index, 1, 0, time.UTC), <-triggered)
+	}
+
+	timer.Stop()
+	assert.Equal(t, int64(4), timer.Stats().Triggered)
+}
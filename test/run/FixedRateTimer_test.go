@@ -1,6 +1,7 @@
 package test_run
 
 import (
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -9,10 +10,10 @@ import (
 )
 
 func TestTimerWithCallback(t *testing.T) {
-	counter := 0
+	var counter int32
 
 	timer := run.NewFixedRateTimerFromCallback(
-		func() { counter++ },
+		func() { atomic.AddInt32(&counter, 1) },
 		100, 0,
 	)
 
@@ -20,5 +21,113 @@ func TestTimerWithCallback(t *testing.T) {
 	time.Sleep(time.Millisecond * 500)
 	timer.Stop()
 
-	assert.True(t, counter > 3)
+	assert.True(t, atomic.LoadInt32(&counter) > 3)
+}
+
+func TestTimerDropsMissedTriggers(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	timer := run.NewFixedRateTimerFromCallback(func() {}, 100, 0)
+	timer.SetClock(clock)
+	timer.Start()
+
+	// The clock jumps over several intervals like after a stall
+	clock.BlockUntil(1)
+	clock.Advance(550 * time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(t, int64(1), timer.Stats().Triggered)
+
+	// The next trigger keeps the original schedule
+	clock.Advance(49 * time.Millisecond)
+	assert.Equal(t, 1, clock.Waiters())
+	clock.Advance(time.Millisecond)
+	clock.BlockUntil(1)
+	assert.Equal(t, int64(2), timer.Stats().Triggered)
+
+	timer.Stop()
+}
+
+func TestTimerOverlapPolicies(t *testing.T) {
+	for _, policy := range []run.TimerOverlapPolicy{run.OverlapSkip, run.OverlapQueue, run.OverlapConcurrent} {
+		var running, maxRunning int32
+
+		timer := run.NewFixedRateTimerFromCallback(func() {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 70)
+			atomic.AddInt32(&running, -1)
+		}, 20, 0)
+		timer.SetOverlap(policy)
+
+		timer.Start()
+		time.Sleep(time.Millisecond * 300)
+		timer.Stop()
+		time.Sleep(time.Millisecond * 100)
+
+		stats := timer.Stats()
+		assert.True(t, stats.Triggered > 5)
+		assert.Equal(t, int64(0), stats.Running)
+		assert.Equal(t, int64(0), stats.Queued)
+
+		switch policy {
+		case run.OverlapSkip:
+			assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+			assert.True(t, stats.Skipped > 0)
+			assert.Equal(t, stats.Triggered-stats.Skipped, stats.Completed)
+		case run.OverlapQueue:
+			assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+			assert.Equal(t, int64(0), stats.Skipped)
+		case run.OverlapConcurrent:
+			assert.True(t, atomic.LoadInt32(&maxRunning) > 1)
+			assert.Equal(t, stats.Triggered, stats.Completed)
+		}
+	}
+}
+
+func TestTimerRecoversPanics(t *testing.T) {
+	var counter int32
+
+	timer := run.NewFixedRateTimerFromCallback(func() {
+		atomic.AddInt32(&counter, 1)
+		panic("Callback failed")
+	}, 30, 0)
+	timer.SetJitter(10)
+	assert.Equal(t, 10, timer.Jitter())
+
+	timer.Start()
+	assert.True(t, timer.IsStarted())
+	time.Sleep(time.Millisecond * 200)
+	timer.Close("")
+	assert.False(t, timer.IsStarted())
+	time.Sleep(time.Millisecond * 20)
+
+	stats := timer.Stats()
+	assert.True(t, atomic.LoadInt32(&counter) > 2)
+	assert.Equal(t, int64(atomic.LoadInt32(&counter)), stats.Failed)
+	assert.NotNil(t, stats.LastError)
+	assert.True(t, stats.MaxDuration >= stats.LastDuration)
+}
+
+func TestTimerConcurrentAccess(t *testing.T) {
+	timer := run.NewFixedRateTimerFromCallback(func() {}, 1, 0)
+
+	done := make(chan bool)
+	go func() {
+		for index := 0; index < 100; index++ {
+			timer.SetCallback(func() {})
+			timer.SetInterval(1 + index%3)
+		}
+		done <- true
+	}()
+
+	for index := 0; index < 50; index++ {
+		timer.Start()
+		timer.Stop()
+	}
+	<-done
+	timer.Stop()
 }
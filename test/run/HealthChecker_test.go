@@ -0,0 +1,81 @@
+package test_run
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type healthComponent struct {
+	status string
+	delay  time.Duration
+}
+
+func (c *healthComponent) CheckHealth(ctx context.Context) *run.HealthCheckResult {
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+		}
+	}
+	if c.status == "panic" {
+		panic("Check failed")
+	}
+	return run.NewHealthCheckResult(c.status, map[string]interface{}{"connections": 5})
+}
+
+func TestHealthCheckStatuses(t *testing.T) {
+	report := run.HealthChecker.Check(context.Background(), []interface{}{
+		&healthComponent{status: run.HealthUp},
+		&correlationComponent{opened: true},
+		"not checkable",
+	}, time.Second)
+	assert.Equal(t, run.HealthUp, report.Status)
+	assert.Len(t, report.Components, 2)
+	assert.Equal(t, "*test_run.healthComponent", report.Components[0].Name)
+	assert.Equal(t, 5, report.Components[0].Details["connections"])
+
+	report = run.HealthChecker.Check(context.Background(), []interface{}{
+		&healthComponent{status: run.HealthUp},
+		&healthComponent{status: run.HealthDegraded},
+	}, 0)
+	assert.Equal(t, run.HealthDegraded, report.Status)
+
+	report = run.HealthChecker.Check(context.Background(), []interface{}{
+		&healthComponent{status: run.HealthDegraded},
+		&correlationComponent{opened: false},
+	}, 0)
+	assert.Equal(t, run.HealthDown, report.Status)
+	assert.Equal(t, run.HealthDown, report.Components[1].Status)
+}
+
+func TestHealthCheckTimeoutsAndPanics(t *testing.T) {
+	start := time.Now()
+	report := run.HealthChecker.CheckNamed(context.Background(), map[string]interface{}{
+		"slow":   &healthComponent{status: run.HealthUp, delay: time.Second},
+		"failed": &healthComponent{status: "panic"},
+		"ok":     &healthComponent{status: run.HealthUp, delay: 20 * time.Millisecond},
+	}, 100*time.Millisecond)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+
+	assert.Equal(t, run.HealthDown, report.Status)
+	assert.Len(t, report.Components, 3)
+	assert.Equal(t, "failed", report.Components[0].Name)
+	assert.Equal(t, run.HealthDown, report.Components[0].Status)
+	assert.Contains(t, report.Components[0].Error, "Check failed")
+	assert.Equal(t, "ok", report.Components[1].Name)
+	assert.Equal(t, run.HealthUp, report.Components[1].Status)
+	assert.Equal(t, "slow", report.Components[2].Name)
+	assert.Equal(t, run.HealthDown, report.Components[2].Status)
+	assert.NotEmpty(t, report.Components[2].Error)
+
+	json, err := report.ToJson()
+	assert.Nil(t, err)
+	value := convert.JsonConverter.ToMap(json)
+	assert.Equal(t, "down", value["status"])
+	assert.Len(t, value["components"], 3)
+}
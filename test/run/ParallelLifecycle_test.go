@@ -0,0 +1,122 @@
+package test_run
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type parallelCounter struct {
+	lock    sync.Mutex
+	current int
+	max     int
+}
+
+type parallelComponent struct {
+	counter *parallelCounter
+	delay   time.Duration
+	err     error
+	opened  bool
+}
+
+func (c *parallelComponent) IsOpen() bool {
+	return c.opened
+}
+
+func (c *parallelComponent) Open(ctx context.Context) error {
+	c.counter.lock.Lock()
+	c.counter.current++
+	if c.counter.current > c.counter.max {
+		c.counter.max = c.counter.current
+	}
+	c.counter.lock.Unlock()
+
+	defer func() {
+		c.counter.lock.Lock()
+		c.counter.current--
+		c.counter.lock.Unlock()
+	}()
+
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.err != nil {
+		return c.err
+	}
+	c.opened = true
+	return nil
+}
+
+func (c *parallelComponent) Close(ctx context.Context) error {
+	if c.delay > 0 {
+		time.Sleep(c.delay)
+	}
+	c.opened = false
+	return nil
+}
+
+type panicComponent struct{}
+
+func (c *panicComponent) Close(correlationId string) error {
+	panic("Close failed")
+}
+
+func TestOpenParallelWithLimit(t *testing.T) {
+	counter := &parallelCounter{}
+	components := []interface{}{}
+	for index := 0; index < 10; index++ {
+		components = append(components, &parallelComponent{counter: counter, delay: 20 * time.Millisecond})
+	}
+
+	err := run.Opener.OpenParallel(context.Background(), components, run.NewLifecycleOptions(3, 0))
+	assert.Nil(t, err)
+	assert.True(t, run.Opener.IsOpen(components))
+	assert.Equal(t, 3, counter.max)
+
+	err = run.Closer.CloseParallel(context.Background(), components, nil)
+	assert.Nil(t, err)
+	assert.False(t, run.Opener.IsOpenOne(components[0]))
+}
+
+func TestOpenParallelAggregatesErrors(t *testing.T) {
+	counter := &parallelCounter{}
+	component1 := &parallelComponent{counter: counter}
+	component2 := &parallelComponent{counter: counter, delay: time.Second}
+	component3 := &parallelComponent{counter: counter, err: errors.NewConnectionError("", "NO_CONNECTION", "Connection refused")}
+	components := []interface{}{component1, component2, component3}
+
+	ctx := run.ContextWithCorrelationId(context.Background(), "123")
+	start := time.Now()
+	err := run.Opener.OpenParallel(ctx, components, run.NewLifecycleOptions(0, 50*time.Millisecond))
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "OPEN_FAILED", appErr.Code)
+	assert.Equal(t, "123", appErr.CorrelationId)
+	assert.True(t, component1.opened)
+
+	descriptions := appErr.Details["errors"].([]*errors.ErrorDescription)
+	assert.Len(t, descriptions, 2)
+	assert.Equal(t, "OPEN_TIMEOUT", descriptions[0].Code)
+	assert.Equal(t, "NO_CONNECTION", descriptions[1].Code)
+}
+
+func TestCloseParallelRecoversPanics(t *testing.T) {
+	counter := &parallelCounter{}
+	component := &parallelComponent{counter: counter, opened: true}
+	components := []interface{}{&panicComponent{}, component}
+
+	err := run.Closer.CloseParallel(context.Background(), components, run.NewLifecycleOptions(1, time.Second))
+	assert.NotNil(t, err)
+	assert.Equal(t, "CLOSE_FAILED", err.(*errors.ApplicationError).Code)
+	assert.False(t, component.opened)
+}
@@ -0,0 +1,64 @@
+package test_run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	limiter := run.NewRateLimiter(2, 3)
+	limiter.SetClock(clock)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Acquire("123"))
+	}
+
+	err := limiter.Acquire("123")
+	assert.NotNil(t, err)
+	appErr := err.(*errors.ApplicationError)
+	assert.Equal(t, "RATE_LIMITED", appErr.Code)
+	assert.Equal(t, errors.InvalidState, appErr.Category)
+	assert.Equal(t, int64(500), appErr.Details["retry_after"])
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Nil(t, limiter.Acquire("123"))
+	assert.NotNil(t, limiter.Acquire("123"))
+
+	// Bucket is never filled above the burst
+	clock.Advance(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Acquire("123"))
+	}
+	assert.NotNil(t, limiter.Acquire("123"))
+}
+
+func TestRateLimiterConfigure(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	limiter := run.NewRateLimiter(100, 100)
+	limiter.SetClock(clock)
+	limiter.Configure(config.NewConfigParamsFromTuples(
+		"rate_limit.rate", 1,
+		"rate_limit.burst", 1,
+	))
+
+	calls := 0
+	action := func() (interface{}, error) {
+		calls++
+		return "OK", nil
+	}
+
+	result, err := limiter.Call("123", action)
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+
+	_, err = limiter.Call("123", action)
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(1000), err.(*errors.ApplicationError).Details["retry_after"])
+	assert.Equal(t, 1, calls)
+}
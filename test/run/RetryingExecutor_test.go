@@ -0,0 +1,177 @@
+package test_run
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type flakyComponent struct {
+	failures []error
+	calls    int
+}
+
+func (c *flakyComponent) Execute(correlationId string, args *run.Parameters) (interface{}, error) {
+	c.calls++
+	if c.calls <= len(c.failures) {
+		return nil, c.failures[c.calls-1]
+	}
+	return "OK", nil
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	component := &flakyComponent{failures: []error{
+		errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused"),
+		errors.NewInvocationError("123", "FAILED", "Invocation failed"),
+	}}
+
+	executor := run.NewRetryingExecutor()
+	executor.SetBackoff(run.ConstantBackoff, time.Millisecond, time.Millisecond)
+
+	result, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	assert.Equal(t, 3, component.calls)
+}
+
+func TestDoNotRetryPermanentErrors(t *testing.T) {
+	for _, failure := range []error{
+		errors.NewBadRequestError("123", "BAD_REQUEST", "Bad request"),
+		errors.NewUnauthorizedError("123", "UNAUTHORIZED", "Unauthorized"),
+		context.Canceled,
+	} {
+		component := &flakyComponent{failures: []error{failure}}
+		executor := run.NewRetryingExecutor()
+
+		_, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+		assert.Equal(t, failure, err)
+		assert.Equal(t, 1, component.calls)
+	}
+
+	assert.True(t, run.IsRetriableError(errors.NewConnectionError("", "", "")))
+	assert.False(t, run.IsRetriableError(errors.NewConfigError("", "", "")))
+}
+
+func TestRetryAttemptsAndExponentialBackoff(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	failure := errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused")
+	component := &flakyComponent{failures: []error{failure, failure, failure, failure, failure}}
+
+	executor := run.NewRetryingExecutor()
+	executor.Configure(config.NewConfigParamsFromTuples(
+		"retries.attempts", 4,
+		"retries.backoff", "exponential",
+		"retries.delay", 100,
+		"retries.max_delay", 300,
+	))
+	executor.SetClock(clock)
+	assert.Equal(t, 4, executor.Attempts())
+
+	done := make(chan error)
+	go func() {
+		_, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+		done <- err
+	}()
+
+	for _, delay := range []time.Duration{100, 200, 300} {
+		clock.BlockUntil(1)
+		clock.Advance(delay*time.Millisecond - time.Millisecond)
+		assert.Equal(t, 1, clock.Waiters())
+		clock.Advance(time.Millisecond)
+	}
+
+	assert.Equal(t, failure, <-done)
+	assert.Equal(t, 4, component.calls)
+}
+
+func TestRetryExponentialBackoffWithoutMaxDelay(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	failure := errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused")
+	failures := make([]error, 100)
+	for index := range failures {
+		failures[index] = failure
+	}
+	component := &flakyComponent{failures: failures}
+
+	executor := run.NewRetryingExecutor()
+	executor.SetAttempts(100)
+	executor.SetBackoff(run.ExponentialBackoff, time.Second, 0)
+	executor.SetClock(clock)
+
+	done := make(chan error)
+	go func() {
+		_, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+		done <- err
+	}()
+
+	// Delays double until they reach the maximum duration and never overflow
+	delay := time.Second
+	for attempt := 1; attempt < 100; attempt++ {
+		clock.BlockUntil(1)
+		clock.Advance(delay - time.Nanosecond)
+		assert.Equal(t, 1, clock.Waiters())
+		clock.Advance(time.Nanosecond)
+
+		if delay > time.Duration(math.MaxInt64)/2 {
+			delay = time.Duration(math.MaxInt64)
+		} else {
+			delay *= 2
+		}
+	}
+
+	assert.Equal(t, failure, <-done)
+	assert.Equal(t, 100, component.calls)
+}
+
+func TestRetryTimeout(t *testing.T) {
+	clock := run.NewFakeClock(time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC))
+	failure := errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused")
+	component := &flakyComponent{failures: []error{failure, failure, failure, failure, failure}}
+
+	executor := run.NewRetryingExecutor()
+	executor.Configure(config.NewConfigParamsFromTuples(
+		"retries.attempts", 10,
+		"retries.timeout", 250,
+		"retries.backoff", "constant",
+		"retries.delay", 100,
+	))
+	executor.SetClock(clock)
+	assert.Equal(t, 250*time.Millisecond, executor.Timeout())
+	assert.Equal(t, run.ConstantBackoff, executor.Backoff())
+
+	done := make(chan error)
+	go func() {
+		_, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(100 * time.Millisecond)
+	clock.BlockUntil(1)
+	clock.Advance(100 * time.Millisecond)
+
+	assert.Equal(t, failure, <-done)
+	assert.Equal(t, 3, component.calls)
+}
+
+func TestRetryDecorrelatedJitter(t *testing.T) {
+	failure := errors.NewConnectionError("123", "NO_CONNECTION", "Connection refused")
+	component := &flakyComponent{failures: []error{failure, failure, failure}}
+
+	executor := run.NewRetryingExecutor()
+	executor.SetAttempts(5)
+	executor.SetBackoff(run.DecorrelatedJitterBackoff, time.Millisecond, 5*time.Millisecond)
+
+	start := time.Now()
+	result, err := executor.ExecuteOne("123", component, run.NewEmptyParameters())
+	assert.Nil(t, err)
+	assert.Equal(t, "OK", result)
+	assert.True(t, time.Since(start) >= 3*time.Millisecond)
+	assert.True(t, time.Since(start) < time.Second)
+}
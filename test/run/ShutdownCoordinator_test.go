@@ -0,0 +1,121 @@
+package test_run
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/config"
+	"github.com/pip-services3-go/pip-services3-commons-go/errors"
+	"github.com/pip-services3-go/pip-services3-commons-go/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type shutdownLog struct {
+	lock  sync.Mutex
+	items []string
+}
+
+func (c *shutdownLog) add(item string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.items = append(c.items, item)
+}
+
+type shutdownComponent struct {
+	name  string
+	log   *shutdownLog
+	delay time.Duration
+	err   error
+}
+
+func (c *shutdownComponent) Close(ctx context.Context) error {
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if c.err != nil {
+		return c.err
+	}
+	c.log.add(c.name)
+	return nil
+}
+
+type hungComponent struct{}
+
+func (c *hungComponent) Close(correlationId string) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestShutdownInPriorityOrder(t *testing.T) {
+	log := &shutdownLog{}
+	coordinator := run.NewShutdownCoordinator()
+	coordinator.Register("persistence", &shutdownComponent{name: "persistence", log: log}, 0)
+	coordinator.Register("endpoint", &shutdownComponent{name: "endpoint", log: log}, 100)
+	coordinator.Register("controller", &shutdownComponent{name: "controller", log: log}, 50)
+	coordinator.Register("cache", &shutdownComponent{name: "cache", log: log, delay: 20 * time.Millisecond}, 50)
+	assert.False(t, coordinator.IsShuttingDown())
+
+	report := coordinator.Shutdown("123")
+	assert.True(t, coordinator.IsShuttingDown())
+	assert.True(t, report.IsComplete())
+	assert.Equal(t, "requested", report.Reason)
+	assert.Equal(t, []string{"endpoint", "controller", "cache", "persistence"}, report.Closed)
+	assert.Equal(t, []string{"endpoint", "controller", "cache", "persistence"}, log.items)
+
+	// Repeated calls return the same report
+	assert.Equal(t, report, coordinator.Shutdown("123"))
+	assert.Equal(t, report, coordinator.Wait())
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	log := &shutdownLog{}
+	coordinator := run.NewShutdownCoordinator()
+	coordinator.Configure(config.NewConfigParamsFromTuples(
+		"shutdown.timeout", 100,
+		"shutdown.drain_period", 10,
+	))
+	coordinator.Register("hung", &hungComponent{}, 10)
+	coordinator.Register("slow", &shutdownComponent{name: "slow", log: log, delay: time.Second}, 10)
+	coordinator.Register("failed", &shutdownComponent{name: "failed", log: log,
+		err: errors.NewInvalidStateError("", "FAILED", "Close failed")}, 10)
+	coordinator.Register("late", &shutdownComponent{name: "late", log: log}, 0)
+
+	start := time.Now()
+	report := coordinator.Shutdown("123")
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+
+	assert.False(t, report.IsComplete())
+	assert.Equal(t, []string{"hung", "slow", "late"}, report.TimedOut)
+	assert.Equal(t, "Close failed", report.Failed["failed"])
+	assert.Len(t, report.Closed, 0)
+}
+
+func TestShutdownOnSignal(t *testing.T) {
+	log := &shutdownLog{}
+	coordinator := run.NewShutdownCoordinator()
+	coordinator.SetTimeout(time.Second)
+	coordinator.Register("endpoint", &shutdownComponent{name: "endpoint", log: log}, 0)
+	coordinator.ListenSignals(os.Interrupt)
+
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(os.Interrupt); err != nil {
+		t.Skip("Signals are not supported on this platform")
+	}
+
+	select {
+	case <-coordinator.Done():
+	case <-time.After(time.Second):
+		assert.Fail(t, "Shutdown was not triggered by signal")
+	}
+
+	report := coordinator.Wait()
+	assert.Equal(t, "signal: interrupt", report.Reason)
+	assert.Equal(t, []string{"endpoint"}, report.Closed)
+}
@@ -0,0 +1,119 @@
+package test_validate
+
+import (
+	"testing"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+	"github.com/pip-services3-go/pip-services3-commons-go/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonSchemaFromObjectSchema(t *testing.T) {
+	schema := validate.NewObjectSchema().
+		WithRequiredProperty("name", convert.String, validate.NewValueComparisonRule("LIKE", "^[A-Z]")).
+		WithOptionalProperty("age", convert.Integer,
+			validate.NewValueComparisonRule(">=", 0),
+			validate.NewValueComparisonRule("<", 150),
+		).
+		WithOptionalProperty("tags", validate.NewArraySchema(convert.String)).
+		WithOptionalProperty("attributes", validate.NewMapSchema(convert.String, convert.Double)).
+		WithOptionalProperty("created", convert.DateTime)
+
+	result := validate.JsonSchemaConverter.ToJsonSchema(schema)
+
+	assert.Equal(t, validate.JsonSchemaDialect, result["$schema"])
+	assert.Equal(t, "object", result["type"])
+	assert.Equal(t, false, result["additionalProperties"])
+	assert.Equal(t, []string{"name"}, result["required"])
+
+	properties := result["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string", "pattern": "^[A-Z]"}, properties["name"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "integer", "format": "int32", "minimum": 0, "exclusiveMaximum": 150,
+	}, properties["age"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "array", "items": map[string]interface{}{"type": "string"},
+	}, properties["tags"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "object", "additionalProperties": map[string]interface{}{"type": "number", "format": "double"},
+	}, properties["attributes"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["created"])
+}
+
+func TestJsonSchemaFromRules(t *testing.T) {
+	schema := validate.NewObjectSchema().
+		WithOptionalProperty("status", convert.String,
+			validate.NewIncludedRule("new", "done"),
+			validate.NewExcludedRule("deleted"),
+		).
+		WithOptionalProperty("email", convert.String).
+		WithOptionalProperty("phone", convert.String)
+	schema.WithRule(validate.NewAtLeastOneExistsRule("email", "phone"))
+	schema.WithRule(validate.NewOnlyOneExistsRule("email", "phone"))
+	schema.WithRule(validate.NewNotRule(validate.NewOrRule(
+		validate.NewValueComparisonRule("==", "A"),
+		validate.NewValueComparisonRule("!=", "B"),
+	)))
+
+	result := validate.JsonSchemaConverter.ToJsonSchemaFragment(schema)
+
+	properties := result["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"new", "done"},
+		"not":  map[string]interface{}{"enum": []interface{}{"deleted"}},
+	}, properties["status"])
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"required": []string{"email"}},
+		map[string]interface{}{"required": []string{"phone"}},
+	}, result["anyOf"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"required": []string{"email"}},
+		map[string]interface{}{"required": []string{"phone"}},
+	}, result["oneOf"])
+	assert.Equal(t, map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"const": "A"},
+			map[string]interface{}{"not": map[string]interface{}{"const": "B"}},
+		},
+	}, result["not"])
+	assert.Nil(t, result["$schema"])
+}
+
+type customRule struct{}
+
+func (c *customRule) Validate(path string, schema validate.ISchema, value interface{}) []*validate.ValidationResult {
+	return []*validate.ValidationResult{}
+}
+
+func TestJsonSchemaSkipsCustomRules(t *testing.T) {
+	schema := validate.NewPropertySchemaWithRules("status", convert.String, false, []validate.IValidationRule{
+		validate.NewNotRule(&customRule{}),
+		validate.NewOrRule(&customRule{}, validate.NewValueComparisonRule("==", "A")),
+	})
+
+	result := validate.JsonSchemaConverter.ToJsonSchemaFragment(schema)
+	assert.Equal(t, map[string]interface{}{
+		"type": "string",
+		"anyOf": []interface{}{
+			map[string]interface{}{"const": "A"},
+		},
+	}, result)
+
+	result = validate.JsonSchemaConverter.ToJsonSchemaFragment(
+		validate.NewSchema().WithRule(validate.NewOrRule(&customRule{})),
+	)
+	assert.Equal(t, map[string]interface{}{}, result)
+}
+
+func TestJsonSchemaToJson(t *testing.T) {
+	schema := validate.NewObjectSchema().
+		WithRequiredProperty("name", convert.String).
+		WithOptionalProperty("age", convert.Integer, validate.NewValueComparisonRule(">=", 0))
+
+	json, err := validate.JsonSchemaConverter.ToJson(schema)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":false,`+
+		`"properties":{"age":{"format":"int32","minimum":0,"type":"integer"},"name":{"type":"string"}},"required":["name"],"type":"object"}`, json)
+}
@@ -0,0 +1,293 @@
+package validate
+
+import (
+	refl "reflect"
+	"strings"
+
+	"github.com/pip-services3-go/pip-services3-commons-go/convert"
+)
+
+// URI of JSON Schema dialect used in converted schemas.
+const JsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+/*
+Converts validation schemas into JSON Schema documents.
+
+Object, property, array and map schemas are converted into "object", "array" and typed schemas.
+Validation rules are converted into matching JSON Schema keywords:
+
+IncludedRule - "enum"
+
+ExcludedRule - "not" with "enum"
+
+ValueComparisonRule - "const", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum" or "pattern"
+
+AndRule, OrRule, NotRule - "allOf", "anyOf" and "not"
+
+AtLeastOneExistsRule, OnlyOneExistsRule - "anyOf" and "oneOf" with required properties
+
+PropertiesComparisonRule has no JSON Schema equivalent and is written to "x-properties-comparison" extension.
+
+see
+ObjectSchema
+
+Example:
+ schema := NewObjectSchema().
+ 	WithRequiredProperty("name", convert.String).
+ 	WithOptionalProperty("age", convert.Integer, NewValueComparisonRule(">=", 0))
+
+ jsonSchema := JsonSchemaConverter.ToJsonSchema(schema)
+ json, _ := JsonSchemaConverter.ToJson(schema)
+ fmt.Println(json)
+ // {"$schema":"https://json-schema.org/draft/2020-12/schema","additionalProperties":false,
+ //  "properties":{"age":{"format":"int32","minimum":0,"type":"integer"},"name":{"type":"string"}},"required":["name"],"type":"object"}
+*/
+type TJsonSchemaConverter struct{}
+
+var JsonSchemaConverter *TJsonSchemaConverter = &TJsonSchemaConverter{}
+
+// Converts a validation schema or a type into JSON Schema document.
+// Parameters:
+//  - schema interface{}
+//  a validation schema, TypeCode, type name or reflect.Type to convert.
+// Returns map[string]interface{}
+// the JSON Schema document.
+func (c *TJsonSchemaConverter) ToJsonSchema(schema interface{}) map[string]interface{} {
+	result := c.ToJsonSchemaFragment(schema)
+	result["$schema"] = JsonSchemaDialect
+	return result
+}
+
+// Converts a validation schema or a type into JSON Schema without "$schema" keyword
+// to be embedded into other documents.
+// Parameters:
+//  - schema interface{}
+//  a validation schema, TypeCode, type name or reflect.Type to convert.
+// Returns map[string]interface{}
+// the JSON Schema.
+func (c *TJsonSchemaConverter) ToJsonSchemaFragment(schema interface{}) map[string]interface{} {
+	switch s := schema.(type) {
+	case *ObjectSchema:
+		return c.objectToJsonSchema(s)
+	case *PropertySchema:
+		return c.withRules(c.ToJsonSchemaFragment(s.Type()), s.Rules())
+	case *ArraySchema:
+		result := map[string]interface{}{"type": "array"}
+		if s.ValueType() != nil {
+			result["items"] = c.ToJsonSchemaFragment(s.ValueType())
+		}
+		return c.withRules(result, s.Rules())
+	case *MapSchema:
+		result := map[string]interface{}{"type": "object"}
+		if s.ValueType() != nil {
+			result["additionalProperties"] = c.ToJsonSchemaFragment(s.ValueType())
+		}
+		return c.withRules(result, s.Rules())
+	case *Schema:
+		return c.withRules(map[string]interface{}{}, s.Rules())
+	}
+
+	return c.typeToJsonSchema(schema)
+}
+
+// Converts a validation schema or a type into JSON string with JSON Schema document.
+// Parameters:
+//  - schema interface{}
+//  a validation schema, TypeCode, type name or reflect.Type to convert.
+// Returns string, error
+// the JSON Schema document as JSON string and conversion error.
+func (c *TJsonSchemaConverter) ToJson(schema interface{}) (string, error) {
+	return convert.ToJson(c.ToJsonSchema(schema))
+}
+
+func (c *TJsonSchemaConverter) objectToJsonSchema(schema *ObjectSchema) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+
+	for _, property := range schema.Properties() {
+		properties[property.Name()] = c.ToJsonSchemaFragment(property)
+		if property.Required() {
+			required = append(required, property.Name())
+		}
+	}
+
+	result := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": schema.UndefinedAllowed(),
+	}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return c.withRules(result, schema.Rules())
+}
+
+func (c *TJsonSchemaConverter) typeToJsonSchema(typ interface{}) map[string]interface{} {
+	if typ == nil {
+		return map[string]interface{}{}
+	}
+
+	var typeCode convert.TypeCode
+	switch t := typ.(type) {
+	case convert.TypeCode:
+		typeCode = t
+	case refl.Type:
+		typeCode = convert.TypeConverter.ToTypeCode(t)
+	case string:
+		typeCode = convert.Unknown
+		for code := convert.Unknown; code <= convert.Map; code++ {
+			if strings.EqualFold(t, convert.TypeConverter.ToString(code)) {
+				typeCode = code
+			}
+		}
+	default:
+		typeCode = convert.Unknown
+		if value := convert.IntegerConverter.ToNullableInteger(typ); value != nil {
+			typeCode = convert.TypeCode(*value)
+		}
+	}
+
+	switch typeCode {
+	case convert.String:
+		return map[string]interface{}{"type": "string"}
+	case convert.Boolean:
+		return map[string]interface{}{"type": "boolean"}
+	case convert.Integer:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case convert.Long:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case convert.Float:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case convert.Double:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case convert.DateTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case convert.Duration:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case convert.Object, convert.Map:
+		return map[string]interface{}{"type": "object"}
+	case convert.Array:
+		return map[string]interface{}{"type": "array"}
+	}
+	return map[string]interface{}{}
+}
+
+// Adds keywords of validation rules to the schema.
+// Keywords of rules are merged into the schema when they don't conflict
+// with existing keywords, otherwise the rules are added to "allOf".
+func (c *TJsonSchemaConverter) withRules(schema map[string]interface{}, rules []IValidationRule) map[string]interface{} {
+	conflicting := []interface{}{}
+
+	for _, rule := range rules {
+		fragment := c.ruleToJsonSchema(rule)
+		if len(fragment) == 0 {
+			continue
+		}
+
+		conflict := false
+		for key := range fragment {
+			if _, ok := schema[key]; ok {
+				conflict = true
+			}
+		}
+
+		if conflict {
+			conflicting = append(conflicting, fragment)
+			continue
+		}
+		for key, value := range fragment {
+			schema[key] = value
+		}
+	}
+
+	if len(conflicting) > 0 {
+		if allOf, ok := schema["allOf"].([]interface{}); ok {
+			conflicting = append(allOf, conflicting...)
+		}
+		schema["allOf"] = conflicting
+	}
+	return schema
+}
+
+func (c *TJsonSchemaConverter) rulesToJsonSchema(rules []IValidationRule) []interface{} {
+	result := []interface{}{}
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		// Rules without JSON Schema equivalent are skipped
+		if fragment := c.ruleToJsonSchema(rule); len(fragment) > 0 {
+			result = append(result, fragment)
+		}
+	}
+	return result
+}
+
+func (c *TJsonSchemaConverter) requiredProperties(properties []string) []interface{} {
+	result := []interface{}{}
+	for _, property := range properties {
+		result = append(result, map[string]interface{}{
+			"required": []string{property},
+		})
+	}
+	return result
+}
+
+func (c *TJsonSchemaConverter) ruleToJsonSchema(rule IValidationRule) map[string]interface{} {
+	switch r := rule.(type) {
+	case *IncludedRule:
+		if len(r.values) > 0 {
+			return map[string]interface{}{"enum": r.values}
+		}
+	case *ExcludedRule:
+		if len(r.values) > 0 {
+			return map[string]interface{}{"not": map[string]interface{}{"enum": r.values}}
+		}
+	case *ValueComparisonRule:
+		return c.comparisonToJsonSchema(r.operation, r.value)
+	case *AndRule:
+		if fragments := c.rulesToJsonSchema(r.rules); len(fragments) > 0 {
+			return map[string]interface{}{"allOf": fragments}
+		}
+	case *OrRule:
+		if fragments := c.rulesToJsonSchema(r.rules); len(fragments) > 0 {
+			return map[string]interface{}{"anyOf": fragments}
+		}
+	case *NotRule:
+		if r.rule != nil {
+			// Negation of an empty schema rejects all values
+			if fragment := c.ruleToJsonSchema(r.rule); len(fragment) > 0 {
+				return map[string]interface{}{"not": fragment}
+			}
+		}
+	case *AtLeastOneExistsRule:
+		return map[string]interface{}{"anyOf": c.requiredProperties(r.properties)}
+	case *OnlyOneExistsRule:
+		return map[string]interface{}{"oneOf": c.requiredProperties(r.properties)}
+	case *PropertiesComparisonRule:
+		return map[string]interface{}{
+			"x-properties-comparison": r.property1 + " " + r.operation + " " + r.property2,
+		}
+	}
+	return map[string]interface{}{}
+}
+
+func (c *TJsonSchemaConverter) comparisonToJsonSchema(operation string, value interface{}) map[string]interface{} {
+	switch strings.ToUpper(operation) {
+	case "=", "==", "EQ":
+		return map[string]interface{}{"const": value}
+	case "!=", "<>", "NE":
+		return map[string]interface{}{"not": map[string]interface{}{"const": value}}
+	case "<", "LT":
+		return map[string]interface{}{"exclusiveMaximum": value}
+	case "<=", "LE", "LTE":
+		return map[string]interface{}{"maximum": value}
+	case ">", "GT":
+		return map[string]interface{}{"exclusiveMinimum": value}
+	case ">=", "GE", "GTE":
+		return map[string]interface{}{"minimum": value}
+	case "LIKE":
+		return map[string]interface{}{"pattern": convert.StringConverter.ToString(value)}
+	}
+	return map[string]interface{}{}
+}